@@ -0,0 +1,168 @@
+package binrels
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Maximal returns the undominated elements: x such that yRx implies xRy,
+// i.e. nothing in the upper section of x is strictly better than x.
+func Maximal(a [][]bool) []int {
+	res := []int{}
+	for x := range a {
+		lower := BottomIntersection(a, x)
+		dominated := false
+		for _, y := range TopIntersection(a, x) {
+			if !slices.Contains(lower, y) {
+				dominated = true
+				break
+			}
+		}
+		if !dominated {
+			res = append(res, x)
+		}
+	}
+	return res
+}
+
+func Minimal(a [][]bool) []int {
+	return Maximal(Transpose(a))
+}
+
+// Greatest returns the elements related to every other element.
+func Greatest(a [][]bool) []int {
+	res := []int{}
+	for x := range a {
+		lower := BottomIntersection(a, x)
+		if len(lower) == len(a) || len(lower) == len(a)-1 && !a[x][x] {
+			res = append(res, x)
+		}
+	}
+	return res
+}
+
+func Least(a [][]bool) []int {
+	return Greatest(Transpose(a))
+}
+
+// Core returns the Neumann–Morgenstern solution of an acyclic relation: the
+// internally stable set (no two members related) that dominates every element
+// outside it. For acyclic relations it exists and is unique; a cycle is
+// reported as *CycleError.
+func Core(a [][]bool) ([]int, error) {
+	order, err := TopologicalSort(a)
+	if err != nil {
+		return nil, err
+	}
+
+	inCore := make([]bool, len(a))
+	for _, x := range order {
+		inCore[x] = true
+		for _, y := range TopIntersection(a, x) {
+			if inCore[y] {
+				inCore[x] = false
+				break
+			}
+		}
+	}
+
+	res := []int{}
+	for x := range a {
+		if inCore[x] {
+			res = append(res, x)
+		}
+	}
+	return res, nil
+}
+
+// KSection returns S_k(x) of Makarov's k-max method. The pair (x, y) is split
+// into strict preference P (xRy, not yRx), indifference I (xRy and yRx) and
+// incomparability N (neither), and S_k(x) collects every y with
+//
+//	k=1: P ∪ I ∪ N,  k=2: P ∪ N,  k=3: P ∪ I,  k=4: P.
+func KSection(a [][]bool, x, k int) ([]int, error) {
+	if k < 1 || k > 4 {
+		return nil, fmt.Errorf("k must be in 1..4, got %d", k)
+	}
+	if x < 0 || x >= len(a) {
+		return nil, fmt.Errorf("element %d out of range", x)
+	}
+
+	res := []int{}
+	for y := range a {
+		p := a[x][y] && !a[y][x]
+		i := a[x][y] && a[y][x]
+		n := !a[x][y] && !a[y][x]
+
+		var in bool
+		switch k {
+		case 1:
+			in = p || i || n
+		case 2:
+			in = p || n
+		case 3:
+			in = p || i
+		case 4:
+			in = p
+		}
+		if in {
+			res = append(res, y)
+		}
+	}
+	return res, nil
+}
+
+// KMax returns the k-max elements: x whose S_k(x) contains S_k(y) for every y.
+// Unlike Core it does not require the relation to be acyclic.
+func KMax(a [][]bool, k int) ([]int, error) {
+	sections := make([][]int, len(a))
+	for x := range a {
+		s, err := KSection(a, x, k)
+		if err != nil {
+			return nil, err
+		}
+		sections[x] = s
+	}
+
+	res := []int{}
+	for x := range a {
+		greatest := true
+		for y := range a {
+			if !isSubset(sections[y], sections[x]) {
+				greatest = false
+				break
+			}
+		}
+		if greatest {
+			res = append(res, x)
+		}
+	}
+	return res, nil
+}
+
+// KOpt returns the k-optimal elements: k-max elements with S_k(x) equal to
+// the whole carrier set.
+func KOpt(a [][]bool, k int) ([]int, error) {
+	max, err := KMax(a, k)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []int{}
+	for _, x := range max {
+		s, _ := KSection(a, x, k)
+		if len(s) == len(a) {
+			res = append(res, x)
+		}
+	}
+	return res, nil
+}
+
+func isSubset(sub, super []int) bool {
+	for _, v := range sub {
+		if !slices.Contains(super, v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,131 @@
+package binrels
+
+// Change is the result of a closure or reduction together with the edges
+// that had to be added to or removed from the original relation.
+type Change struct {
+	Result  [][]bool
+	Added   [][2]int
+	Removed [][2]int
+}
+
+func Compare(before, after [][]bool) Change {
+	ch := Change{Result: after, Added: [][2]int{}, Removed: [][2]int{}}
+	for i := range after {
+		for j := range after[i] {
+			switch {
+			case after[i][j] && !before[i][j]:
+				ch.Added = append(ch.Added, [2]int{i, j})
+			case !after[i][j] && before[i][j]:
+				ch.Removed = append(ch.Removed, [2]int{i, j})
+			}
+		}
+	}
+	return ch
+}
+
+func ReflexiveClosure(a [][]bool) Change {
+	return Compare(a, orZero(Union(a, Identity(len(a)))))
+}
+
+func SymmetricClosure(a [][]bool) Change {
+	return Compare(a, orZero(Union(a, Transpose(a))))
+}
+
+func TransitiveClosureChange(a [][]bool) Change {
+	return Compare(a, orZero(TransitiveClosure(a)))
+}
+
+// EquivalenceClosure is the smallest equivalence containing a: the
+// reflexive-transitive closure of its symmetric closure.
+func EquivalenceClosure(a [][]bool) Change {
+	sym := SymmetricClosure(a).Result
+	return Compare(a, orZero(Reachability(sym)))
+}
+
+// TransitiveReduction returns a minimal relation with the same transitive
+// closure. Acyclic parts keep exactly the edges not implied by longer paths.
+// Every strongly connected component with more than one element is replaced
+// by a single cycle through its elements, so the result need not be a subset
+// of the input when cycles are present.
+func TransitiveReduction(a [][]bool) Change {
+	n := len(a)
+	res := Zero(n)
+	if n == 0 {
+		return Compare(a, res)
+	}
+
+	cond := Condense(a)
+	for _, comp := range cond.Components {
+		if len(comp) == 1 {
+			v := comp[0]
+			res[v][v] = a[v][v]
+			continue
+		}
+		for k := range comp {
+			res[comp[k]][comp[(k+1)%len(comp)]] = true
+		}
+	}
+
+	closure := TransitiveClosure(cond.Relation)
+	m := len(cond.Components)
+	for p := 0; p < m; p++ {
+		for q := 0; q < m; q++ {
+			if !cond.Relation[p][q] {
+				continue
+			}
+			implied := false
+			for k := 0; k < m; k++ {
+				if k != p && k != q && closure[p][k] && closure[k][q] {
+					implied = true
+					break
+				}
+			}
+			if !implied {
+				i, j := representativeEdge(a, cond.Components[p], cond.Components[q])
+				res[i][j] = true
+			}
+		}
+	}
+
+	return Compare(a, res)
+}
+
+func representativeEdge(a [][]bool, from, to []int) (int, int) {
+	for _, i := range from {
+		for _, j := range to {
+			if a[i][j] {
+				return i, j
+			}
+		}
+	}
+	return from[0], to[0]
+}
+
+// ReflexiveTransitiveKernel returns the preorder contained in the reflexive
+// closure R of a that is induced by its traces: xKy iff every z with zRx also
+// has zRy and every z with yRz also has xRz. K is always reflexive and
+// transitive, K ⊆ R, and K equals R exactly when R is already a preorder.
+func ReflexiveTransitiveKernel(a [][]bool) Change {
+	r := ReflexiveClosure(a).Result
+	n := len(r)
+	res := Zero(n)
+	for x := 0; x < n; x++ {
+		for y := 0; y < n; y++ {
+			holds := true
+			for z := 0; z < n && holds; z++ {
+				if r[z][x] && !r[z][y] || r[y][z] && !r[x][z] {
+					holds = false
+				}
+			}
+			res[x][y] = holds
+		}
+	}
+	return Compare(a, res)
+}
+
+func orZero(m [][]bool) [][]bool {
+	if m == nil {
+		return Zero(0)
+	}
+	return m
+}
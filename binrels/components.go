@@ -0,0 +1,145 @@
+package binrels
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StronglyConnectedComponents returns the equivalence classes of
+// MutualReachability, each sorted and ordered by their smallest element.
+func StronglyConnectedComponents(a [][]bool) [][]int {
+	r, err := FromMatrix(a)
+	if err != nil || len(a) == 0 {
+		return nil
+	}
+	return r.StronglyConnectedComponents()
+}
+
+func (r *Relation) StronglyConnectedComponents() [][]int {
+	mutual := r.MutualReachability()
+	assigned := make([]bool, r.n)
+	res := [][]int{}
+	for i := 0; i < r.n; i++ {
+		if assigned[i] {
+			continue
+		}
+		component := mutual.Row(i)
+		for _, v := range component {
+			assigned[v] = true
+		}
+		res = append(res, component)
+	}
+	return res
+}
+
+// Condensation is the quotient of a relation by its strongly connected
+// components. Relation[p][q] holds when some element of component p is
+// related to some element of a different component q.
+type Condensation struct {
+	Components [][]int
+	Of         []int
+	Relation   [][]bool
+}
+
+func Condense(a [][]bool) Condensation {
+	components := StronglyConnectedComponents(a)
+	of := make([]int, len(a))
+	for c, comp := range components {
+		for _, v := range comp {
+			of[v] = c
+		}
+	}
+
+	quotient := Zero(len(components))
+	for i := range a {
+		for j := range a[i] {
+			if a[i][j] && of[i] != of[j] {
+				quotient[of[i]][of[j]] = true
+			}
+		}
+	}
+
+	return Condensation{Components: components, Of: of, Relation: quotient}
+}
+
+// Order returns the component indices in topological order. The condensation
+// is acyclic by construction, so an order always exists.
+func (c Condensation) Order() []int {
+	order, _ := TopologicalSort(c.Relation)
+	return order
+}
+
+// Elements expands Order back into the original elements, component by component.
+func (c Condensation) Elements() [][]int {
+	res := make([][]int, 0, len(c.Components))
+	for _, p := range c.Order() {
+		res = append(res, c.Components[p])
+	}
+	return res
+}
+
+type CycleError struct {
+	Cycle []int
+}
+
+func (e *CycleError) Error() string {
+	if len(e.Cycle) == 0 {
+		return "relation has a cycle"
+	}
+	parts := make([]string, len(e.Cycle)+1)
+	for i, v := range e.Cycle {
+		parts[i] = fmt.Sprint(v)
+	}
+	parts[len(e.Cycle)] = fmt.Sprint(e.Cycle[0])
+	return "relation has a cycle: " + strings.Join(parts, " -> ")
+}
+
+// TopologicalSort orders the elements so that iRj implies i comes before j.
+// A loop or a longer cycle makes this impossible and is returned as *CycleError.
+func TopologicalSort(a [][]bool) ([]int, error) {
+	n := len(a)
+	indegree := make([]int, n)
+	for i := range a {
+		for j := range a[i] {
+			if a[i][j] {
+				indegree[j]++
+			}
+		}
+	}
+
+	queue := []int{}
+	for i := 0; i < n; i++ {
+		if indegree[i] == 0 {
+			queue = append(queue, i)
+		}
+	}
+
+	order := make([]int, 0, n)
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		order = append(order, v)
+		for u := 0; u < n; u++ {
+			if !a[v][u] {
+				continue
+			}
+			indegree[u]--
+			if indegree[u] == 0 {
+				queue = append(queue, u)
+			}
+		}
+	}
+
+	if len(order) == n {
+		return order, nil
+	}
+
+	for i := 0; i < n; i++ {
+		if indegree[i] > 0 {
+			if cycle := shortestCycle(a, i); cycle != nil {
+				return nil, &CycleError{Cycle: cycle}
+			}
+		}
+	}
+	return nil, &CycleError{}
+}
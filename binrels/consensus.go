@@ -0,0 +1,193 @@
+package binrels
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// exactKemenyLimit bounds the subset DP, which takes O(2^n·n²) time.
+const exactKemenyLimit = 16
+
+// Distance is the Kemeny–Snell distance: the number of pairs in SymmDiff.
+// Relations of different sizes have distance -1.
+func Distance(a, b [][]bool) int {
+	if len(a) != len(b) {
+		return -1
+	}
+	if len(a) == 0 {
+		return 0
+	}
+
+	cnt := 0
+	for _, row := range SymmDiff(a, b) {
+		for _, v := range row {
+			if v {
+				cnt++
+			}
+		}
+	}
+	return cnt
+}
+
+// Consensus is a Kemeny median: the linear order minimising the total
+// distance to every expert relation.
+type Consensus struct {
+	Order     []int
+	Relation  [][]bool
+	Distances []int
+	Total     int
+	// Exact is false when the order comes from local search.
+	Exact bool
+}
+
+// KemenyMedian searches the median among linear orders, best first. Loops do
+// not depend on the order, so the median keeps a loop exactly when most
+// experts do. Up to exactKemenyLimit alternatives the median is exact;
+// larger profiles fall back to insertion local search from the Copeland order.
+func KemenyMedian(profile [][][]bool) (Consensus, error) {
+	if len(profile) == 0 {
+		return Consensus{}, fmt.Errorf("empty profile")
+	}
+	n := len(profile[0])
+	for e, r := range profile {
+		if len(r) != n {
+			return Consensus{}, fmt.Errorf("expert %d relation has size %d, expected %d", e, len(r), n)
+		}
+	}
+
+	// cost[i][j] is the disagreement caused by ranking i above j
+	cost := make([][]int, n)
+	for i := range cost {
+		cost[i] = make([]int, n)
+		for j := range cost[i] {
+			if i == j {
+				continue
+			}
+			for _, r := range profile {
+				if !r[i][j] {
+					cost[i][j]++
+				}
+				if r[j][i] {
+					cost[i][j]++
+				}
+			}
+		}
+	}
+
+	var order []int
+	exact := n <= exactKemenyLimit
+	if exact {
+		order = kemenyExact(cost)
+	} else {
+		order = kemenyLocalSearch(cost)
+	}
+
+	relation := Zero(n)
+	for p, i := range order {
+		for _, j := range order[p+1:] {
+			relation[i][j] = true
+		}
+	}
+	for i := 0; i < n; i++ {
+		loops := 0
+		for _, r := range profile {
+			if r[i][i] {
+				loops++
+			}
+		}
+		relation[i][i] = 2*loops > len(profile)
+	}
+
+	res := Consensus{Order: order, Relation: relation, Distances: make([]int, len(profile)), Exact: exact}
+	for e, r := range profile {
+		res.Distances[e] = Distance(relation, r)
+		res.Total += res.Distances[e]
+	}
+	return res, nil
+}
+
+// kemenyExact is a DP over the set of alternatives already ranked on top.
+func kemenyExact(cost [][]int) []int {
+	n := len(cost)
+	full := 1 << uint(n)
+	best := make([]int, full)
+	last := make([]int, full)
+	for s := 1; s < full; s++ {
+		best[s] = math.MaxInt
+	}
+
+	for s := 0; s < full; s++ {
+		if best[s] == math.MaxInt {
+			continue
+		}
+		for j := 0; j < n; j++ {
+			if s&(1<<uint(j)) != 0 {
+				continue
+			}
+			c := best[s]
+			for i := 0; i < n; i++ {
+				if s&(1<<uint(i)) != 0 {
+					c += cost[i][j]
+				}
+			}
+			next := s | 1<<uint(j)
+			if c < best[next] {
+				best[next] = c
+				last[next] = j
+			}
+		}
+	}
+
+	order := make([]int, n)
+	for s, k := full-1, n-1; k >= 0; k-- {
+		order[k] = last[s]
+		s &^= 1 << uint(last[s])
+	}
+	return order
+}
+
+func orderCost(cost [][]int, order []int) int {
+	total := 0
+	for p, i := range order {
+		for _, j := range order[p+1:] {
+			total += cost[i][j]
+		}
+	}
+	return total
+}
+
+func kemenyLocalSearch(cost [][]int) []int {
+	n := len(cost)
+	order := make([]int, n)
+	score := make([]int, n)
+	for i := range order {
+		order[i] = i
+		for j := 0; j < n; j++ {
+			score[i] += cost[j][i] - cost[i][j]
+		}
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return score[b] - score[a]
+	})
+
+	current := orderCost(cost, order)
+	for improved := true; improved; {
+		improved = false
+		for from := 0; from < n; from++ {
+			for to := 0; to < n; to++ {
+				if from == to {
+					continue
+				}
+				candidate := slices.Clone(order)
+				x := candidate[from]
+				candidate = slices.Delete(candidate, from, from+1)
+				candidate = slices.Insert(candidate, to, x)
+				if c := orderCost(cost, candidate); c < current {
+					order, current, improved = candidate, c, true
+				}
+			}
+		}
+	}
+	return order
+}
@@ -0,0 +1,126 @@
+package binrels
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+var dotColors = []string{"#f4cccc", "#cfe2f3", "#d9ead3", "#fce5cd", "#d9d2e9", "#d0e0e3"}
+
+type DOTOptions struct {
+	Name   string
+	Labels []string
+	// HighlightComponents fills every non-trivial strongly connected
+	// component with its own colour and wraps it in a cluster.
+	HighlightComponents bool
+	// Arrowless draws undirected edges, as in Hasse diagrams.
+	Arrowless bool
+	BottomUp  bool
+}
+
+func (o DOTOptions) label(i int) string {
+	if i < len(o.Labels) {
+		return o.Labels[i]
+	}
+	return strconv.Itoa(i)
+}
+
+// WriteDOT exports the relation as a Graphviz digraph. Loops are kept as
+// self edges.
+func WriteDOT(w io.Writer, a [][]bool, opts DOTOptions) error {
+	name := opts.Name
+	if name == "" {
+		name = "R"
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "digraph %s {\n", strconv.Quote(name))
+	if opts.BottomUp {
+		fmt.Fprintln(bw, "\trankdir=BT;")
+	}
+	fmt.Fprintln(bw, "\tnode [shape=circle];")
+	if opts.Arrowless {
+		fmt.Fprintln(bw, "\tedge [arrowhead=none];")
+	}
+
+	clustered := make([]bool, len(a))
+	if opts.HighlightComponents {
+		color := 0
+		for _, comp := range StronglyConnectedComponents(a) {
+			if len(comp) == 1 && !a[comp[0]][comp[0]] {
+				continue
+			}
+			fmt.Fprintf(bw, "\tsubgraph cluster_%d {\n", color)
+			fmt.Fprintln(bw, "\t\tstyle=dashed;")
+			for _, v := range comp {
+				fmt.Fprintf(bw, "\t\t%d [label=%s, style=filled, fillcolor=%s];\n",
+					v, strconv.Quote(opts.label(v)), strconv.Quote(dotColors[color%len(dotColors)]))
+				clustered[v] = true
+			}
+			fmt.Fprintln(bw, "\t}")
+			color++
+		}
+	}
+
+	for i := range a {
+		if !clustered[i] {
+			fmt.Fprintf(bw, "\t%d [label=%s];\n", i, strconv.Quote(opts.label(i)))
+		}
+	}
+	for i := range a {
+		for j := range a[i] {
+			if a[i][j] {
+				fmt.Fprintf(bw, "\t%d -> %d;\n", i, j)
+			}
+		}
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// Hasse returns the covering relation of a partial order (reflexive or
+// strict): x covers-below y when x < y and no z lies strictly between them.
+// Reflexive and transitive edges are dropped.
+func Hasse(a [][]bool) ([][]bool, error) {
+	if !Check(a, Antisymmetric, Transitive) {
+		return nil, fmt.Errorf("relation is not a partial order")
+	}
+
+	n := len(a)
+	strict := func(i, j int) bool {
+		return i != j && a[i][j]
+	}
+	res := Zero(n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if !strict(i, j) {
+				continue
+			}
+			covers := true
+			for k := 0; k < n; k++ {
+				if strict(i, k) && strict(k, j) {
+					covers = false
+					break
+				}
+			}
+			res[i][j] = covers
+		}
+	}
+	return res, nil
+}
+
+// WriteHasseDOT exports the Hasse diagram with lesser elements at the
+// bottom. In this package xRy reads "x is at least y", so edges run from
+// the covered element up to the covering one.
+func WriteHasseDOT(w io.Writer, a [][]bool, opts DOTOptions) error {
+	h, err := Hasse(a)
+	if err != nil {
+		return err
+	}
+	opts.Arrowless = true
+	opts.BottomUp = true
+	opts.HighlightComponents = false
+	return WriteDOT(w, Transpose(h), opts)
+}
@@ -0,0 +1,407 @@
+package binrels
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultLabels names elements by their index, for formats without labels.
+func DefaultLabels(n int) []string {
+	labels := make([]string, n)
+	for i := range labels {
+		labels[i] = strconv.Itoa(i)
+	}
+	return labels
+}
+
+// contentLines yields trimmed non-empty lines with '#' comments stripped.
+func contentLines(r io.Reader, f func(lineNo int, line string) error) error {
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+		if idx := strings.IndexByte(line, '#'); idx >= 0 {
+			line = line[:idx]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if err := f(lineNo, line); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// ReadMatrix reads a 0/1 matrix, one row per line. Cells may be separated by
+// spaces, commas or '|', or written together as in "0011".
+func ReadMatrix(r io.Reader) ([][]bool, error) {
+	m := [][]bool{}
+	err := contentLines(r, func(lineNo int, line string) error {
+		fields := strings.FieldsFunc(line, func(c rune) bool {
+			return c == ' ' || c == '\t' || c == ',' || c == '|'
+		})
+		if len(fields) == 1 {
+			fields = strings.Split(fields[0], "")
+		}
+
+		row := make([]bool, len(fields))
+		for j, f := range fields {
+			switch f {
+			case "0":
+			case "1":
+				row[j] = true
+			default:
+				return fmt.Errorf("line %d: unexpected cell %q", lineNo, f)
+			}
+		}
+		m = append(m, row)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range m {
+		if len(m[i]) != len(m) {
+			return nil, fmt.Errorf("row %d has %d cells, expected %d", i, len(m[i]), len(m))
+		}
+	}
+	return m, nil
+}
+
+func WriteMatrix(w io.Writer, a [][]bool) error {
+	bw := bufio.NewWriter(w)
+	for i := range a {
+		for j := range a[i] {
+			if j > 0 {
+				bw.WriteByte(' ')
+			}
+			if a[i][j] {
+				bw.WriteByte('1')
+			} else {
+				bw.WriteByte('0')
+			}
+		}
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+type labelIndex struct {
+	labels []string
+	index  map[string]int
+}
+
+func (l *labelIndex) get(label string) int {
+	if i, ok := l.index[label]; ok {
+		return i
+	}
+	l.index[label] = len(l.labels)
+	l.labels = append(l.labels, label)
+	return len(l.labels) - 1
+}
+
+// ReadPairs reads one "a -> b" pair per line. A line with a single label
+// declares an element without relations. Labels are numbered in order of
+// first appearance.
+func ReadPairs(r io.Reader) ([]string, [][]bool, error) {
+	labels := &labelIndex{index: map[string]int{}}
+	pairs := [][2]int{}
+
+	err := contentLines(r, func(lineNo int, line string) error {
+		parts := strings.Split(line, "->")
+		switch len(parts) {
+		case 1:
+			labels.get(line)
+		case 2:
+			from, to := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+			if from == "" || to == "" {
+				return fmt.Errorf("line %d: empty label in %q", lineNo, line)
+			}
+			pairs = append(pairs, [2]int{labels.get(from), labels.get(to)})
+		default:
+			return fmt.Errorf("line %d: expected \"a -> b\", got %q", lineNo, line)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := Zero(len(labels.labels))
+	for _, p := range pairs {
+		m[p[0]][p[1]] = true
+	}
+	return labels.labels, m, nil
+}
+
+// checkPairLabel rejects labels ReadPairs would split, cut or trim.
+func checkPairLabel(label string) error {
+	switch {
+	case label == "":
+		return fmt.Errorf("empty label")
+	case strings.Contains(label, "->"), strings.ContainsAny(label, "#\r\n"):
+		return fmt.Errorf("label %q cannot be written as a pair", label)
+	case strings.TrimSpace(label) != label:
+		return fmt.Errorf("label %q has surrounding spaces", label)
+	}
+	return nil
+}
+
+// WritePairs writes every pair as "a -> b"; isolated elements are written
+// on their own so the carrier survives a round trip. Labels that ReadPairs
+// could not read back are an error.
+func WritePairs(w io.Writer, labels []string, a [][]bool) error {
+	for _, l := range labels {
+		if err := checkPairLabel(l); err != nil {
+			return err
+		}
+	}
+	bw := bufio.NewWriter(w)
+	for i := range a {
+		related := false
+		for j := range a[i] {
+			if a[i][j] || a[j][i] {
+				related = true
+				break
+			}
+		}
+		if !related {
+			fmt.Fprintln(bw, labels[i])
+		}
+	}
+	for i := range a {
+		for j := range a[i] {
+			if a[i][j] {
+				fmt.Fprintf(bw, "%s -> %s\n", labels[i], labels[j])
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// ReadCSV reads a matrix with a header row of column labels and a label in
+// the first cell of every row. Rows may come in any order.
+func ReadCSV(r io.Reader) ([]string, [][]bool, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil, fmt.Errorf("empty csv")
+	}
+
+	labels := records[0][1:]
+	index := make(map[string]int, len(labels))
+	for i, l := range labels {
+		if _, ok := index[l]; ok {
+			return nil, nil, fmt.Errorf("duplicate label %q in header", l)
+		}
+		index[l] = i
+	}
+	if len(records)-1 != len(labels) {
+		return nil, nil, fmt.Errorf("csv has %d rows, header has %d labels", len(records)-1, len(labels))
+	}
+
+	m := Zero(len(labels))
+	seen := make([]bool, len(labels))
+	for _, rec := range records[1:] {
+		i, ok := index[rec[0]]
+		if !ok {
+			return nil, nil, fmt.Errorf("row label %q is not in header", rec[0])
+		}
+		if seen[i] {
+			return nil, nil, fmt.Errorf("duplicate row %q", rec[0])
+		}
+		seen[i] = true
+		for j, cell := range rec[1:] {
+			switch strings.TrimSpace(cell) {
+			case "0", "":
+			case "1":
+				m[i][j] = true
+			default:
+				return nil, nil, fmt.Errorf("row %q: unexpected cell %q", rec[0], cell)
+			}
+		}
+	}
+	return labels, m, nil
+}
+
+func WriteCSV(w io.Writer, labels []string, a [][]bool) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(append([]string{""}, labels...)); err != nil {
+		return err
+	}
+	for i := range a {
+		rec := make([]string, len(a[i])+1)
+		rec[0] = labels[i]
+		for j := range a[i] {
+			if a[i][j] {
+				rec[j+1] = "1"
+			} else {
+				rec[j+1] = "0"
+			}
+		}
+		if err := cw.Write(rec); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// jsonRelation is the on-disk JSON shape. Either Pairs or Matrix may be
+// given; Elements is optional for pairs.
+type jsonRelation struct {
+	Elements []string   `json:"elements,omitempty"`
+	Pairs    [][]string `json:"pairs,omitempty"`
+	Matrix   [][]int    `json:"matrix,omitempty"`
+}
+
+func checkDuplicates(labels []string) error {
+	seen := make(map[string]bool, len(labels))
+	for _, e := range labels {
+		if seen[e] {
+			return fmt.Errorf("duplicate element %q", e)
+		}
+		seen[e] = true
+	}
+	return nil
+}
+
+func ReadJSON(r io.Reader) ([]string, [][]bool, error) {
+	var data jsonRelation
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, nil, err
+	}
+
+	if data.Matrix != nil {
+		if data.Pairs != nil {
+			return nil, nil, fmt.Errorf("json relation has both pairs and matrix")
+		}
+		labels := data.Elements
+		if labels == nil {
+			labels = DefaultLabels(len(data.Matrix))
+		}
+		if len(labels) != len(data.Matrix) {
+			return nil, nil, fmt.Errorf("matrix has %d rows, %d elements given", len(data.Matrix), len(labels))
+		}
+		if err := checkDuplicates(labels); err != nil {
+			return nil, nil, err
+		}
+		m := Zero(len(labels))
+		for i, row := range data.Matrix {
+			if len(row) != len(labels) {
+				return nil, nil, fmt.Errorf("matrix row %d has %d cells, expected %d", i, len(row), len(labels))
+			}
+			for j, v := range row {
+				if v != 0 && v != 1 {
+					return nil, nil, fmt.Errorf("matrix cell (%d, %d) is %d, expected 0 or 1", i, j, v)
+				}
+				m[i][j] = v == 1
+			}
+		}
+		return labels, m, nil
+	}
+
+	if err := checkDuplicates(data.Elements); err != nil {
+		return nil, nil, err
+	}
+	labels := &labelIndex{index: map[string]int{}}
+	for _, e := range data.Elements {
+		labels.get(e)
+	}
+	declared := len(data.Elements) > 0
+	for i, p := range data.Pairs {
+		if len(p) != 2 {
+			return nil, nil, fmt.Errorf("pair %d has %d elements, expected 2", i, len(p))
+		}
+		for _, e := range p {
+			if _, ok := labels.index[e]; declared && !ok {
+				return nil, nil, fmt.Errorf("pair element %q is not declared", e)
+			}
+			labels.get(e)
+		}
+	}
+
+	m := Zero(len(labels.labels))
+	for _, p := range data.Pairs {
+		m[labels.index[p[0]]][labels.index[p[1]]] = true
+	}
+	return labels.labels, m, nil
+}
+
+func WriteJSON(w io.Writer, labels []string, a [][]bool) error {
+	data := jsonRelation{Elements: labels, Pairs: [][]string{}}
+	for i := range a {
+		for j := range a[i] {
+			if a[i][j] {
+				data.Pairs = append(data.Pairs, []string{labels[i], labels[j]})
+			}
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(data)
+}
+
+// ReadFile picks the format by extension: .csv, .json, .pairs/.rel for pair
+// lists and anything else as a 0/1 matrix labelled by index.
+func ReadFile(path string) ([]string, [][]bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return ReadCSV(f)
+	case ".json":
+		return ReadJSON(f)
+	case ".pairs", ".rel":
+		return ReadPairs(f)
+	default:
+		m, err := ReadMatrix(f)
+		if err != nil {
+			return nil, nil, err
+		}
+		return DefaultLabels(len(m)), m, nil
+	}
+}
+
+func WriteFile(path string, labels []string, a [][]bool) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		err = WriteCSV(f, labels, a)
+	case ".json":
+		err = WriteJSON(f, labels, a)
+	case ".pairs", ".rel":
+		err = WritePairs(f, labels, a)
+	default:
+		err = WriteMatrix(f, a)
+	}
+
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,260 @@
+package binrels
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+type ShapeError struct {
+	Op    string
+	Left  [2]int
+	Right [2]int
+}
+
+func (e *ShapeError) Error() string {
+	return fmt.Sprintf("%s: shape mismatch %dx%d and %dx%d", e.Op, e.Left[0], e.Left[1], e.Right[0], e.Right[1])
+}
+
+// Hetero is a relation between two different sets A×B, stored like Relation
+// as packed row bitsets: rows are elements of A, columns elements of B.
+type Hetero struct {
+	rows   int
+	cols   int
+	stride int
+	bits   []uint64
+}
+
+func NewHetero(rows, cols int) *Hetero {
+	if rows < 0 {
+		rows = 0
+	}
+	if cols < 0 {
+		cols = 0
+	}
+	stride := words(cols)
+	return &Hetero{rows: rows, cols: cols, stride: stride, bits: make([]uint64, rows*stride)}
+}
+
+// HeteroFromMatrix converts a rectangular [][]bool. Ragged rows are an error.
+func HeteroFromMatrix(m [][]bool) (*Hetero, error) {
+	cols := 0
+	if len(m) > 0 {
+		cols = len(m[0])
+	}
+	h := NewHetero(len(m), cols)
+	for i := range m {
+		if len(m[i]) != cols {
+			return nil, fmt.Errorf("row %d has %d columns, expected %d", i, len(m[i]), cols)
+		}
+		for j := range m[i] {
+			if m[i][j] {
+				h.Set(i, j)
+			}
+		}
+	}
+	return h, nil
+}
+
+func (r *Relation) Hetero() *Hetero {
+	h := NewHetero(r.n, r.n)
+	copy(h.bits, r.bits)
+	return h
+}
+
+// Square converts back to a homogeneous Relation when both sets have the same size.
+func (h *Hetero) Square() (*Relation, error) {
+	if h.rows != h.cols {
+		return nil, fmt.Errorf("square: %dx%d relation is not square", h.rows, h.cols)
+	}
+	r := New(h.rows)
+	copy(r.bits, h.bits)
+	return r, nil
+}
+
+func (h *Hetero) Shape() [2]int {
+	return [2]int{h.rows, h.cols}
+}
+
+func (h *Hetero) Matrix() [][]bool {
+	m := make([][]bool, h.rows)
+	for i := range m {
+		m[i] = make([]bool, h.cols)
+		for j := range m[i] {
+			m[i][j] = h.Has(i, j)
+		}
+	}
+	return m
+}
+
+func (h *Hetero) row(i int) []uint64 {
+	return h.bits[i*h.stride : (i+1)*h.stride]
+}
+
+func (h *Hetero) Has(i, j int) bool {
+	if i < 0 || j < 0 || i >= h.rows || j >= h.cols {
+		return false
+	}
+	return h.bits[i*h.stride+j/wordSize]&(1<<(uint(j)%wordSize)) != 0
+}
+
+func (h *Hetero) Set(i, j int) {
+	if i < 0 || j < 0 || i >= h.rows || j >= h.cols {
+		return
+	}
+	h.bits[i*h.stride+j/wordSize] |= 1 << (uint(j) % wordSize)
+}
+
+func (h *Hetero) Unset(i, j int) {
+	if i < 0 || j < 0 || i >= h.rows || j >= h.cols {
+		return
+	}
+	h.bits[i*h.stride+j/wordSize] &^= 1 << (uint(j) % wordSize)
+}
+
+func (h *Hetero) Clone() *Hetero {
+	c := NewHetero(h.rows, h.cols)
+	copy(c.bits, h.bits)
+	return c
+}
+
+func (h *Hetero) Equal(o *Hetero) bool {
+	if h.Shape() != o.Shape() {
+		return false
+	}
+	for i := range h.bits {
+		if h.bits[i] != o.bits[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Image returns the elements of B related to a, i.e. row a.
+func (h *Hetero) Image(a int) []int {
+	if a < 0 || a >= h.rows {
+		return nil
+	}
+	res := []int{}
+	for w, word := range h.row(a) {
+		for word != 0 {
+			res = append(res, w*wordSize+bits.TrailingZeros64(word))
+			word &= word - 1
+		}
+	}
+	return res
+}
+
+// Preimage returns the elements of A related to b, i.e. column b.
+func (h *Hetero) Preimage(b int) []int {
+	if b < 0 || b >= h.cols {
+		return nil
+	}
+	res := []int{}
+	for i := 0; i < h.rows; i++ {
+		if h.Has(i, b) {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+// Domain returns the elements of A related to at least one element of B.
+func (h *Hetero) Domain() []int {
+	res := []int{}
+	for i := 0; i < h.rows; i++ {
+		for _, word := range h.row(i) {
+			if word != 0 {
+				res = append(res, i)
+				break
+			}
+		}
+	}
+	return res
+}
+
+// Range returns the elements of B related to at least one element of A.
+func (h *Hetero) Range() []int {
+	acc := make([]uint64, h.stride)
+	for i := 0; i < h.rows; i++ {
+		for w, word := range h.row(i) {
+			acc[w] |= word
+		}
+	}
+
+	res := []int{}
+	for w, word := range acc {
+		for word != 0 {
+			res = append(res, w*wordSize+bits.TrailingZeros64(word))
+			word &= word - 1
+		}
+	}
+	return res
+}
+
+func (h *Hetero) Transpose() *Hetero {
+	res := NewHetero(h.cols, h.rows)
+	for i := 0; i < h.rows; i++ {
+		for _, j := range h.Image(i) {
+			res.Set(j, i)
+		}
+	}
+	return res
+}
+
+func (h *Hetero) Complement() *Hetero {
+	res := NewHetero(h.rows, h.cols)
+	for i := 0; i < h.rows; i++ {
+		src, dst := h.row(i), res.row(i)
+		for w := range src {
+			dst[w] = ^src[w]
+		}
+		if rem := h.cols % wordSize; rem != 0 {
+			dst[h.stride-1] &= 1<<uint(rem) - 1
+		}
+	}
+	return res
+}
+
+func (h *Hetero) wordwise(op string, o *Hetero, f func(a, b uint64) uint64) (*Hetero, error) {
+	if h.Shape() != o.Shape() {
+		return nil, &ShapeError{Op: op, Left: h.Shape(), Right: o.Shape()}
+	}
+	res := NewHetero(h.rows, h.cols)
+	for i := range h.bits {
+		res.bits[i] = f(h.bits[i], o.bits[i])
+	}
+	return res, nil
+}
+
+func (h *Hetero) Union(o *Hetero) (*Hetero, error) {
+	return h.wordwise("union", o, func(a, b uint64) uint64 { return a | b })
+}
+
+func (h *Hetero) Intersection(o *Hetero) (*Hetero, error) {
+	return h.wordwise("intersection", o, func(a, b uint64) uint64 { return a & b })
+}
+
+func (h *Hetero) Diff(o *Hetero) (*Hetero, error) {
+	return h.wordwise("diff", o, func(a, b uint64) uint64 { return a &^ b })
+}
+
+func (h *Hetero) SymmDiff(o *Hetero) (*Hetero, error) {
+	return h.wordwise("symmdiff", o, func(a, b uint64) uint64 { return a ^ b })
+}
+
+// Composition of h ⊆ A×B with o ⊆ B×C gives A×C.
+func (h *Hetero) Composition(o *Hetero) (*Hetero, error) {
+	if h.cols != o.rows {
+		return nil, &ShapeError{Op: "composition", Left: h.Shape(), Right: o.Shape()}
+	}
+	res := NewHetero(h.rows, o.cols)
+	for i := 0; i < h.rows; i++ {
+		dst := res.row(i)
+		for _, k := range h.Image(i) {
+			for w, v := range o.row(k) {
+				dst[w] |= v
+			}
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,272 @@
+package labeled
+
+import (
+	"decision-theory/binrels"
+	"fmt"
+	"slices"
+)
+
+// Relation is a binary relation over an explicit carrier set. Element order
+// in the carrier fixes the row/column order of the underlying matrix.
+type Relation[T comparable] struct {
+	carrier []T
+	index   map[T]int
+	matrix  [][]bool
+}
+
+func New[T comparable](carrier []T) (*Relation[T], error) {
+	index := make(map[T]int, len(carrier))
+	for i, x := range carrier {
+		if _, ok := index[x]; ok {
+			return nil, fmt.Errorf("duplicate element %v in carrier", x)
+		}
+		index[x] = i
+	}
+
+	return &Relation[T]{
+		carrier: slices.Clone(carrier),
+		index:   index,
+		matrix:  binrels.Zero(len(carrier)),
+	}, nil
+}
+
+// FromPairs builds a relation from (x, y) pairs. A nil carrier is inferred
+// from the pairs in order of first appearance.
+func FromPairs[T comparable](carrier []T, pairs ...[2]T) (*Relation[T], error) {
+	if carrier == nil {
+		carrier = []T{}
+		for _, p := range pairs {
+			for _, x := range p {
+				if !slices.Contains(carrier, x) {
+					carrier = append(carrier, x)
+				}
+			}
+		}
+	}
+
+	r, err := New(carrier)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pairs {
+		if err := r.Add(p[0], p[1]); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func FromMatrix[T comparable](carrier []T, matrix [][]bool) (*Relation[T], error) {
+	if len(matrix) != len(carrier) {
+		return nil, fmt.Errorf("matrix has %d rows, carrier has %d elements", len(matrix), len(carrier))
+	}
+	for i := range matrix {
+		if len(matrix[i]) != len(carrier) {
+			return nil, fmt.Errorf("matrix row %d has %d columns, carrier has %d elements", i, len(matrix[i]), len(carrier))
+		}
+	}
+
+	r, err := New(carrier)
+	if err != nil {
+		return nil, err
+	}
+	r.matrix = binrels.Copy(matrix)
+	if r.matrix == nil {
+		r.matrix = binrels.Zero(0)
+	}
+	return r, nil
+}
+
+func (r *Relation[T]) Carrier() []T {
+	return slices.Clone(r.carrier)
+}
+
+func (r *Relation[T]) Size() int {
+	return len(r.carrier)
+}
+
+func (r *Relation[T]) Index(x T) (int, bool) {
+	i, ok := r.index[x]
+	return i, ok
+}
+
+func (r *Relation[T]) Matrix() [][]bool {
+	return binrels.Copy(r.matrix)
+}
+
+func (r *Relation[T]) lookup(x T) (int, error) {
+	i, ok := r.index[x]
+	if !ok {
+		return 0, fmt.Errorf("element %v is not in the carrier", x)
+	}
+	return i, nil
+}
+
+func (r *Relation[T]) Has(x, y T) bool {
+	i, ok := r.index[x]
+	j, ok2 := r.index[y]
+	return ok && ok2 && r.matrix[i][j]
+}
+
+func (r *Relation[T]) set(x, y T, v bool) error {
+	i, err := r.lookup(x)
+	if err != nil {
+		return err
+	}
+	j, err := r.lookup(y)
+	if err != nil {
+		return err
+	}
+	r.matrix[i][j] = v
+	return nil
+}
+
+func (r *Relation[T]) Add(x, y T) error {
+	return r.set(x, y, true)
+}
+
+func (r *Relation[T]) Remove(x, y T) error {
+	return r.set(x, y, false)
+}
+
+func (r *Relation[T]) Pairs() [][2]T {
+	res := [][2]T{}
+	for i := range r.matrix {
+		for j := range r.matrix[i] {
+			if r.matrix[i][j] {
+				res = append(res, [2]T{r.carrier[i], r.carrier[j]})
+			}
+		}
+	}
+	return res
+}
+
+func (r *Relation[T]) elements(idx []int) []T {
+	res := make([]T, len(idx))
+	for k, i := range idx {
+		res[k] = r.carrier[i]
+	}
+	return res
+}
+
+func (r *Relation[T]) DefinitionDomain() []T {
+	return r.elements(binrels.DefinitionDomain(r.matrix))
+}
+
+func (r *Relation[T]) MeaningDomain() []T {
+	return r.elements(binrels.MeaningDomain(r.matrix))
+}
+
+// Upper returns the upper section of x: every y with yRx.
+func (r *Relation[T]) Upper(x T) ([]T, error) {
+	i, err := r.lookup(x)
+	if err != nil {
+		return nil, err
+	}
+	return r.elements(binrels.TopIntersection(r.matrix, i)), nil
+}
+
+// Lower returns the lower section of x: every y with xRy.
+func (r *Relation[T]) Lower(x T) ([]T, error) {
+	i, err := r.lookup(x)
+	if err != nil {
+		return nil, err
+	}
+	return r.elements(binrels.BottomIntersection(r.matrix, i)), nil
+}
+
+func (r *Relation[T]) with(matrix [][]bool) *Relation[T] {
+	return &Relation[T]{carrier: r.carrier, index: r.index, matrix: matrix}
+}
+
+// Align returns o reordered to the carrier order of r. Both carriers must
+// contain the same elements.
+func (r *Relation[T]) Align(o *Relation[T]) (*Relation[T], error) {
+	if len(r.carrier) != len(o.carrier) {
+		return nil, fmt.Errorf("carriers differ in size: %d and %d", len(r.carrier), len(o.carrier))
+	}
+
+	perm := make([]int, len(r.carrier))
+	for i, x := range r.carrier {
+		j, ok := o.index[x]
+		if !ok {
+			return nil, fmt.Errorf("element %v is missing from the other carrier", x)
+		}
+		perm[i] = j
+	}
+
+	matrix := binrels.Zero(len(perm))
+	for i := range perm {
+		for j := range perm {
+			matrix[i][j] = o.matrix[perm[i]][perm[j]]
+		}
+	}
+	return r.with(matrix), nil
+}
+
+func (r *Relation[T]) binary(o *Relation[T], op func(a, b [][]bool) [][]bool) (*Relation[T], error) {
+	aligned, err := r.Align(o)
+	if err != nil {
+		return nil, err
+	}
+	if len(r.carrier) == 0 {
+		return r.with(binrels.Zero(0)), nil
+	}
+	return r.with(op(r.matrix, aligned.matrix)), nil
+}
+
+func (r *Relation[T]) Union(o *Relation[T]) (*Relation[T], error) {
+	return r.binary(o, binrels.Union)
+}
+
+func (r *Relation[T]) Intersection(o *Relation[T]) (*Relation[T], error) {
+	return r.binary(o, binrels.Intersection)
+}
+
+func (r *Relation[T]) Diff(o *Relation[T]) (*Relation[T], error) {
+	return r.binary(o, binrels.Diff)
+}
+
+func (r *Relation[T]) SymmDiff(o *Relation[T]) (*Relation[T], error) {
+	return r.binary(o, binrels.SymmDiff)
+}
+
+func (r *Relation[T]) Composition(o *Relation[T]) (*Relation[T], error) {
+	return r.binary(o, binrels.Composition)
+}
+
+func (r *Relation[T]) Equal(o *Relation[T]) bool {
+	aligned, err := r.Align(o)
+	return err == nil && binrels.Equal(r.matrix, aligned.matrix)
+}
+
+func (r *Relation[T]) Transpose() *Relation[T] {
+	return r.with(orZero(binrels.Transpose(r.matrix)))
+}
+
+func (r *Relation[T]) Complement() *Relation[T] {
+	return r.with(orZero(binrels.Complement(r.matrix)))
+}
+
+func (r *Relation[T]) TransitiveClosure() *Relation[T] {
+	return r.with(orZero(binrels.TransitiveClosure(r.matrix)))
+}
+
+func orZero(m [][]bool) [][]bool {
+	if m == nil {
+		return binrels.Zero(0)
+	}
+	return m
+}
+
+func (r *Relation[T]) Labels() []string {
+	labels := make([]string, len(r.carrier))
+	for i, x := range r.carrier {
+		labels[i] = fmt.Sprint(x)
+	}
+	return labels
+}
+
+func (r *Relation[T]) Print() {
+	binrels.PrintWithSource(r.Labels(), r.matrix)
+}
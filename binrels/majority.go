@@ -0,0 +1,131 @@
+package binrels
+
+import "fmt"
+
+// PairwiseCounts returns N where N[i][j] is the number of voters ranking i
+// above j. Every ranking lists all n alternatives once, best first.
+func PairwiseCounts(n int, profile [][]int) ([][]int, error) {
+	counts := make([][]int, n)
+	for i := range counts {
+		counts[i] = make([]int, n)
+	}
+
+	for v, ranking := range profile {
+		if len(ranking) != n {
+			return nil, fmt.Errorf("voter %d ranks %d alternatives, expected %d", v, len(ranking), n)
+		}
+		seen := make([]bool, n)
+		for _, x := range ranking {
+			if x < 0 || x >= n || seen[x] {
+				return nil, fmt.Errorf("voter %d: invalid or repeated alternative %d", v, x)
+			}
+			seen[x] = true
+		}
+		for p, i := range ranking {
+			for _, j := range ranking[p+1:] {
+				counts[i][j]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+// Majority returns the strict majority relation: iPj when more voters rank
+// i above j than j above i.
+func Majority(n int, profile [][]int) ([][]bool, error) {
+	counts, err := PairwiseCounts(n, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	return foreachcell(n, func(i, j int) bool {
+		return counts[i][j] > counts[j][i]
+	}), nil
+}
+
+// QualifiedMajority relates i to j when more than the share q of all voters
+// rank i above j. q = 0.5 gives the absolute majority.
+func QualifiedMajority(n int, profile [][]int, q float64) ([][]bool, error) {
+	if q < 0.5 || q >= 1 {
+		return nil, fmt.Errorf("quota must be in [0.5, 1), got %v", q)
+	}
+	counts, err := PairwiseCounts(n, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	voters := float64(len(profile))
+	return foreachcell(n, func(i, j int) bool {
+		return float64(counts[i][j]) > q*voters
+	}), nil
+}
+
+type Condorcet struct {
+	Majority [][]bool
+	// Winner and Loser are -1 when there is none.
+	Winner int
+	Loser  int
+	// Cycles are the strongly connected components of the majority
+	// relation with more than one alternative.
+	Cycles [][]int
+	// Smith is the smallest set beating every outside alternative by
+	// majority; Schwartz is the union of the minimal sets undominated from
+	// outside.
+	Smith    []int
+	Schwartz []int
+}
+
+func CondorcetAnalysis(n int, profile [][]int) (Condorcet, error) {
+	counts, err := PairwiseCounts(n, profile)
+	if err != nil {
+		return Condorcet{}, err
+	}
+
+	strict := foreachcell(n, func(i, j int) bool {
+		return counts[i][j] > counts[j][i]
+	})
+	weak := foreachcell(n, func(i, j int) bool {
+		return i != j && counts[i][j] >= counts[j][i]
+	})
+
+	res := Condorcet{Majority: strict, Winner: -1, Loser: -1, Cycles: [][]int{}}
+	for x := 0; x < n; x++ {
+		if len(BottomIntersection(strict, x)) == n-1 {
+			res.Winner = x
+		}
+		if len(TopIntersection(strict, x)) == n-1 {
+			res.Loser = x
+		}
+	}
+
+	for _, comp := range StronglyConnectedComponents(strict) {
+		if len(comp) > 1 {
+			res.Cycles = append(res.Cycles, comp)
+		}
+	}
+
+	res.Smith = topComponents(weak)
+	res.Schwartz = topComponents(strict)
+	return res, nil
+}
+
+// topComponents returns the union of the strongly connected components that
+// no element outside them is related to, in ascending order.
+func topComponents(a [][]bool) []int {
+	res := []int{}
+	if len(a) == 0 {
+		return res
+	}
+
+	cond := Condense(a)
+	top := make([]bool, len(cond.Components))
+	for p := range top {
+		top[p] = len(TopIntersection(cond.Relation, p)) == 0
+	}
+	for x := range a {
+		if top[cond.Of[x]] {
+			res = append(res, x)
+		}
+	}
+	return res
+}
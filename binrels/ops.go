@@ -10,57 +10,44 @@ func foreachcell(size int, f func(i, j int) bool) [][]bool {
 	return result
 }
 
-func Intersection(a, b [][]bool) [][]bool {
-	if len(a) != len(b) || len(a) == 0 || len(a[0]) != len(b[0]) {
+// The matrix operations below work on relations over one set: both
+// arguments must be square and of the same size, otherwise they return
+// nil. Relations between two different sets belong in Hetero, whose
+// operations report a ShapeError instead.
+
+// binary applies a Relation operation to two square matrices of the same
+// size; any other shapes give nil.
+func binary(a, b [][]bool, op func(r, s *Relation) *Relation) [][]bool {
+	if len(a) != len(b) || len(a) == 0 {
+		return nil
+	}
+	r, err := FromMatrix(a)
+	if err != nil {
+		return nil
+	}
+	s, err := FromMatrix(b)
+	if err != nil {
 		return nil
 	}
+	return op(r, s).Matrix()
+}
 
-	return foreachcell(len(a), func(i int, j int) bool {
-		return a[i][j] && b[i][j]
-	})
+func Intersection(a, b [][]bool) [][]bool {
+	return binary(a, b, (*Relation).Intersection)
 }
 
 func Union(a, b [][]bool) [][]bool {
-	if len(a) != len(b) || len(a) == 0 || len(a[0]) != len(b[0]) {
-		return nil
-	}
-
-	return foreachcell(len(a), func(i int, j int) bool {
-		return a[i][j] || b[i][j]
-	})
+	return binary(a, b, (*Relation).Union)
 }
 
 func Diff(a, b [][]bool) [][]bool {
-	if len(a) != len(b) || len(a) == 0 || len(a[0]) != len(b[0]) {
-		return nil
-	}
-
-	return foreachcell(len(a), func(i int, j int) bool {
-		return a[i][j] && !b[i][j]
-	})
+	return binary(a, b, (*Relation).Diff)
 }
 
 func SymmDiff(a, b [][]bool) [][]bool {
-	if len(a) != len(b) || len(a) == 0 || len(a[0]) != len(b[0]) {
-		return nil
-	}
-
-	return foreachcell(len(a), func(i int, j int) bool {
-		return a[i][j] && !b[i][j] || !a[i][j] && b[i][j]
-	})
+	return binary(a, b, (*Relation).SymmDiff)
 }
 
 func Composition(a, b [][]bool) [][]bool {
-	if len(a) != len(b) || len(a) == 0 || len(a[0]) != len(b[0]) {
-		return nil
-	}
-
-	return foreachcell(len(a), func(i int, j int) bool {
-		for k := 0; k < len(a); k++ {
-			if a[i][k] && b[k][j] {
-				return true
-			}
-		}
-		return false
-	})
+	return binary(a, b, (*Relation).Composition)
 }
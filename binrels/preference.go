@@ -0,0 +1,420 @@
+package binrels
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"math/rand"
+)
+
+// Structure splits a preference relation R into strict preference P
+// (xRy, not yRx), indifference I (xRy and yRx, always reflexive) and
+// incomparability J (neither, for x != y).
+type Structure struct {
+	P [][]bool
+	I [][]bool
+	J [][]bool
+}
+
+func Decompose(a [][]bool) Structure {
+	n := len(a)
+	s := Structure{P: Zero(n), I: Zero(n), J: Zero(n)}
+	for x := 0; x < n; x++ {
+		for y := 0; y < n; y++ {
+			switch {
+			case x == y:
+				s.I[x][y] = true
+			case a[x][y] && !a[y][x]:
+				s.P[x][y] = true
+			case a[x][y] && a[y][x]:
+				s.I[x][y] = true
+			default:
+				s.J[x][y] = true
+			}
+		}
+	}
+	return s
+}
+
+type StructureType int
+
+const (
+	Unstructured StructureType = iota
+	PartialOrderStructure
+	IntervalOrderStructure
+	SemiorderStructure
+	WeakOrderStructure
+)
+
+var structureNames = []string{
+	"unstructured",
+	"partial order",
+	"interval order",
+	"semiorder",
+	"weak order",
+}
+
+func (t StructureType) String() string {
+	if t < 0 || int(t) >= len(structureNames) {
+		return "unknown"
+	}
+	return structureNames[t]
+}
+
+// Type returns the most specific structure P belongs to. Each type in the
+// chain weak order ⊂ semiorder ⊂ interval order ⊂ partial order implies the
+// following ones.
+func (s Structure) Type() StructureType {
+	switch {
+	case IsWeakOrder(s.P):
+		return WeakOrderStructure
+	case IsSemiorder(s.P):
+		return SemiorderStructure
+	case IsIntervalOrder(s.P):
+		return IntervalOrderStructure
+	case Check(s.P, Irreflexive, Transitive):
+		return PartialOrderStructure
+	default:
+		return Unstructured
+	}
+}
+
+func IsWeakOrder(p [][]bool) bool {
+	return Check(p, Asymmetric, NegativelyTransitive)
+}
+
+// IsIntervalOrder checks irreflexivity and the Ferrers property:
+// aPb and cPd imply aPd or cPb.
+func IsIntervalOrder(p [][]bool) bool {
+	if !Check(p, Irreflexive) {
+		return false
+	}
+	n := len(p)
+	for a := 0; a < n; a++ {
+		for b := 0; b < n; b++ {
+			if !p[a][b] {
+				continue
+			}
+			for c := 0; c < n; c++ {
+				for d := 0; d < n; d++ {
+					if p[c][d] && !p[a][d] && !p[c][b] {
+						return false
+					}
+				}
+			}
+		}
+	}
+	return true
+}
+
+// IsSemiorder checks the interval order conditions plus semitransitivity:
+// aPb and bPc imply aPd or dPc.
+func IsSemiorder(p [][]bool) bool {
+	if !IsIntervalOrder(p) {
+		return false
+	}
+	n := len(p)
+	for a := 0; a < n; a++ {
+		for b := 0; b < n; b++ {
+			if !p[a][b] {
+				continue
+			}
+			for c := 0; c < n; c++ {
+				if !p[b][c] {
+					continue
+				}
+				for d := 0; d < n; d++ {
+					if !p[a][d] && !p[d][c] {
+						return false
+					}
+				}
+			}
+		}
+	}
+	return true
+}
+
+// Utility returns u with xPy iff u(x) > u(y); P must be a weak order.
+// u(x) is the number of alternatives x is strictly preferred to.
+func Utility(a [][]bool) ([]float64, error) {
+	p := Decompose(a).P
+	if !IsWeakOrder(p) {
+		return nil, fmt.Errorf("strict preference is not a weak order")
+	}
+
+	u := make([]float64, len(p))
+	for x := range p {
+		u[x] = float64(len(BottomIntersection(p, x)))
+	}
+	return u, nil
+}
+
+// SemiorderUtility returns u and a threshold q with xPy iff u(x) > u(y) + q.
+// It solves the difference constraints u(x) - u(y) >= q+1 for xPy and
+// u(x) - u(y) <= q otherwise with Bellman–Ford, q = n being large enough
+// for any semiorder on n elements.
+func SemiorderUtility(a [][]bool) ([]float64, float64, error) {
+	p := Decompose(a).P
+	if !IsSemiorder(p) {
+		return nil, 0, fmt.Errorf("strict preference is not a semiorder")
+	}
+
+	n := len(p)
+	q := n
+	// edge (from, to, w) encodes u(to) - u(from) <= w
+	type edge struct{ from, to, w int }
+	edges := []edge{}
+	for x := 0; x < n; x++ {
+		for y := 0; y < n; y++ {
+			if x == y {
+				continue
+			}
+			if p[x][y] {
+				edges = append(edges, edge{x, y, -(q + 1)})
+			} else {
+				edges = append(edges, edge{y, x, q})
+			}
+		}
+	}
+
+	dist := make([]int, n)
+	for it := 0; it < n; it++ {
+		changed := false
+		for _, e := range edges {
+			if dist[e.from]+e.w < dist[e.to] {
+				dist[e.to] = dist[e.from] + e.w
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+	for _, e := range edges {
+		if dist[e.from]+e.w < dist[e.to] {
+			return nil, 0, fmt.Errorf("no utility with threshold %d exists", q)
+		}
+	}
+
+	low := 0
+	for _, d := range dist {
+		low = min(low, d)
+	}
+	u := make([]float64, n)
+	for x, d := range dist {
+		u[x] = float64(d - low)
+	}
+	return u, float64(q), nil
+}
+
+// IntervalRepresentation returns intervals [lower(x), upper(x)] with xPy iff
+// lower(x) > upper(y). The strict lower sections of an interval order are
+// nested; lower(x) is the rank of x's section and upper(y) is one less than
+// the rank of the first section containing y.
+func IntervalRepresentation(a [][]bool) ([]float64, []float64, error) {
+	p := Decompose(a).P
+	if !IsIntervalOrder(p) {
+		return nil, nil, fmt.Errorf("strict preference is not an interval order")
+	}
+
+	n := len(p)
+	size := make([]int, n)
+	for x := range p {
+		size[x] = len(BottomIntersection(p, x))
+	}
+
+	// nested sections are ranked by their size
+	ranks := map[int]int{}
+	sizes := []int{}
+	for _, s := range size {
+		if _, ok := ranks[s]; !ok {
+			ranks[s] = 0
+			sizes = append(sizes, s)
+		}
+	}
+	for _, s := range sizes {
+		for _, t := range sizes {
+			if t < s {
+				ranks[s]++
+			}
+		}
+	}
+
+	lower := make([]float64, n)
+	upper := make([]float64, n)
+	for x := range p {
+		lower[x] = float64(ranks[size[x]])
+	}
+	for y := range p {
+		first := len(sizes)
+		for x := range p {
+			if p[x][y] {
+				first = min(first, ranks[size[x]])
+			}
+		}
+		upper[y] = float64(first - 1)
+		if upper[y] < lower[y] {
+			upper[y] = lower[y]
+		}
+	}
+	return lower, upper, nil
+}
+
+// strictOrder returns the strict preference of a, checking it is acyclic.
+func strictOrder(a [][]bool) ([][]bool, error) {
+	p := Decompose(a).P
+	if _, err := TopologicalSort(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// LinearExtensions lists the rankings, best first, consistent with the
+// strict preference of a. At most limit rankings are returned (all when
+// limit <= 0); the flag reports whether the list is complete.
+func LinearExtensions(a [][]bool, limit int) ([][]int, bool, error) {
+	p, err := strictOrder(a)
+	if err != nil {
+		return nil, false, err
+	}
+
+	n := len(p)
+	above := make([]int, n)
+	for x := range p {
+		for y := range p {
+			if p[x][y] {
+				above[y]++
+			}
+		}
+	}
+
+	res := [][]int{}
+	complete := true
+	current := make([]int, 0, n)
+	used := make([]bool, n)
+	var extend func() bool
+	extend = func() bool {
+		if len(current) == n {
+			if limit > 0 && len(res) == limit {
+				complete = false
+				return false
+			}
+			res = append(res, append([]int{}, current...))
+			return true
+		}
+		for x := 0; x < n; x++ {
+			if used[x] || above[x] > 0 {
+				continue
+			}
+			used[x] = true
+			current = append(current, x)
+			for y := range p {
+				if p[x][y] {
+					above[y]--
+				}
+			}
+			ok := extend()
+			for y := range p {
+				if p[x][y] {
+					above[y]++
+				}
+			}
+			current = current[:len(current)-1]
+			used[x] = false
+			if !ok {
+				return false
+			}
+		}
+		return true
+	}
+	extend()
+	return res, complete, nil
+}
+
+// extensionCounter counts linear extensions over the down-sets (placed
+// prefixes) of the order, memoised by bitmask.
+type extensionCounter struct {
+	n     int
+	above []uint64
+	memo  map[uint64]*big.Int
+}
+
+func newExtensionCounter(a [][]bool) (*extensionCounter, error) {
+	p, err := strictOrder(a)
+	if err != nil {
+		return nil, err
+	}
+	if len(p) > wordSize {
+		return nil, fmt.Errorf("counting supports at most %d elements, got %d", wordSize, len(p))
+	}
+
+	c := &extensionCounter{n: len(p), above: make([]uint64, len(p)), memo: map[uint64]*big.Int{}}
+	for x := range p {
+		for y := range p {
+			if p[x][y] {
+				c.above[y] |= 1 << uint(x)
+			}
+		}
+	}
+	return c, nil
+}
+
+func (c *extensionCounter) available(placed uint64, x int) bool {
+	return placed&(1<<uint(x)) == 0 && c.above[x]&^placed == 0
+}
+
+func (c *extensionCounter) count(placed uint64) *big.Int {
+	if bits.OnesCount64(placed) == c.n {
+		return big.NewInt(1)
+	}
+	if v, ok := c.memo[placed]; ok {
+		return v
+	}
+
+	total := new(big.Int)
+	for x := 0; x < c.n; x++ {
+		if c.available(placed, x) {
+			total.Add(total, c.count(placed|1<<uint(x)))
+		}
+	}
+	c.memo[placed] = total
+	return total
+}
+
+func CountLinearExtensions(a [][]bool) (*big.Int, error) {
+	c, err := newExtensionCounter(a)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Set(c.count(0)), nil
+}
+
+// SampleLinearExtension draws a ranking uniformly from all linear
+// extensions: every next element is picked with probability proportional to
+// the number of extensions that start with it.
+func SampleLinearExtension(a [][]bool, rng *rand.Rand) ([]int, error) {
+	c, err := newExtensionCounter(a)
+	if err != nil {
+		return nil, err
+	}
+
+	order := make([]int, 0, c.n)
+	placed := uint64(0)
+	for len(order) < c.n {
+		total := c.count(placed)
+		pick := new(big.Int).Rand(rng, total)
+		for x := 0; x < c.n; x++ {
+			if !c.available(placed, x) {
+				continue
+			}
+			sub := c.count(placed | 1<<uint(x))
+			if pick.Cmp(sub) < 0 {
+				order = append(order, x)
+				placed |= 1 << uint(x)
+				break
+			}
+			pick.Sub(pick, sub)
+		}
+	}
+	return order, nil
+}
@@ -0,0 +1,305 @@
+package binrels
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Property int
+
+const (
+	Reflexive Property = iota
+	Irreflexive
+	Symmetric
+	Asymmetric
+	Antisymmetric
+	Transitive
+	NegativelyTransitive
+	Complete
+	Acyclic
+)
+
+var propertyNames = []string{
+	"reflexive",
+	"irreflexive",
+	"symmetric",
+	"asymmetric",
+	"antisymmetric",
+	"transitive",
+	"negatively transitive",
+	"complete",
+	"acyclic",
+}
+
+func (p Property) String() string {
+	if p < 0 || int(p) >= len(propertyNames) {
+		return "unknown"
+	}
+	return propertyNames[p]
+}
+
+func AllProperties() []Property {
+	props := make([]Property, len(propertyNames))
+	for i := range props {
+		props[i] = Property(i)
+	}
+	return props
+}
+
+// maxWitnesses caps the witnesses kept per property; transitivity alone
+// can fail on O(n³) triples.
+const maxWitnesses = 10
+
+// Report holds the outcome of every property check on a relation.
+// Violations lists up to maxWitnesses witnesses for each failed property:
+// single elements for (ir)reflexivity, pairs for (a/anti)symmetry and
+// completeness, triples for (negative) transitivity and the vertex
+// sequence of a cycle for acyclicity.
+type Report struct {
+	Size       int
+	Holds      map[Property]bool
+	Violations map[Property][][]int
+}
+
+func (r Report) Has(props ...Property) bool {
+	for _, p := range props {
+		if !r.Holds[p] {
+			return false
+		}
+	}
+	return true
+}
+
+func (r Report) String() string {
+	var sb strings.Builder
+	for _, p := range AllProperties() {
+		if r.Holds[p] {
+			sb.WriteString("+ ")
+		} else {
+			sb.WriteString("- ")
+		}
+		sb.WriteString(p.String())
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Validate reports an error unless a is a square matrix.
+func Validate(a [][]bool) error {
+	for i := range a {
+		if len(a[i]) != len(a) {
+			return fmt.Errorf("row %d has %d cells, expected %d", i, len(a[i]), len(a))
+		}
+	}
+	return nil
+}
+
+// Analyze checks every property of the relation; a must be square.
+func Analyze(a [][]bool) (Report, error) {
+	if err := Validate(a); err != nil {
+		return Report{}, fmt.Errorf("binrels: analyze: %w", err)
+	}
+	n := len(a)
+	holds := make(map[Property]bool, len(propertyNames))
+	for _, p := range AllProperties() {
+		holds[p] = true
+	}
+	violations := map[Property][][]int{}
+	add := func(p Property, elems ...int) {
+		holds[p] = false
+		if len(violations[p]) < maxWitnesses {
+			violations[p] = append(violations[p], elems)
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		if !a[i][i] {
+			add(Reflexive, i)
+		} else {
+			add(Irreflexive, i)
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if a[i][j] && !a[j][i] {
+				add(Symmetric, i, j)
+			}
+			if i <= j && a[i][j] && a[j][i] {
+				add(Asymmetric, i, j)
+				if i != j {
+					add(Antisymmetric, i, j)
+				}
+			}
+			// completeness is checked on distinct elements only (connectedness)
+			if i < j && !a[i][j] && !a[j][i] {
+				add(Complete, i, j)
+			}
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			for k := 0; k < n; k++ {
+				if a[i][j] && a[j][k] && !a[i][k] {
+					add(Transitive, i, j, k)
+				}
+				if !a[i][j] && !a[j][k] && a[i][k] {
+					add(NegativelyTransitive, i, j, k)
+				}
+			}
+		}
+	}
+
+	for _, cycle := range cycles(a) {
+		add(Acyclic, cycle...)
+	}
+
+	return Report{Size: n, Holds: holds, Violations: violations}, nil
+}
+
+// Check reports whether a is square and has every listed property.
+func Check(a [][]bool, props ...Property) bool {
+	r, err := Analyze(a)
+	return err == nil && r.Has(props...)
+}
+
+// cycles returns one witness cycle for every element that lies on a cycle
+// and is not already covered by a previously reported cycle.
+func cycles(a [][]bool) [][]int {
+	n := len(a)
+	covered := make([]bool, n)
+	res := [][]int{}
+
+	for start := 0; start < n; start++ {
+		if covered[start] {
+			continue
+		}
+
+		path := shortestCycle(a, start)
+		if path == nil {
+			continue
+		}
+
+		for _, v := range path {
+			covered[v] = true
+		}
+		res = append(res, path)
+	}
+
+	return res
+}
+
+func shortestCycle(a [][]bool, start int) []int {
+	n := len(a)
+	if a[start][start] {
+		return []int{start}
+	}
+
+	prev := make([]int, n)
+	for i := range prev {
+		prev[i] = -1
+	}
+
+	queue := []int{start}
+	visited := make([]bool, n)
+	visited[start] = true
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for u := 0; u < n; u++ {
+			if !a[v][u] {
+				continue
+			}
+			if u == start {
+				path := []int{}
+				for w := v; w != -1; w = prev[w] {
+					path = append(path, w)
+				}
+				for l, r := 0, len(path)-1; l < r; l, r = l+1, r-1 {
+					path[l], path[r] = path[r], path[l]
+				}
+				return path
+			}
+			if !visited[u] {
+				visited[u] = true
+				prev[u] = v
+				queue = append(queue, u)
+			}
+		}
+	}
+
+	return nil
+}
+
+type Class int
+
+const (
+	Equivalence Class = iota
+	Tolerance
+	Preorder
+	PartialOrder
+	StrictPartialOrder
+	WeakOrder
+	StrictWeakOrder
+	LinearOrder
+	StrictLinearOrder
+	Tournament
+)
+
+var classNames = []string{
+	"equivalence",
+	"tolerance",
+	"preorder",
+	"partial order",
+	"strict partial order",
+	"weak order",
+	"strict weak order",
+	"linear order",
+	"strict linear order",
+	"tournament",
+}
+
+func (c Class) String() string {
+	if c < 0 || int(c) >= len(classNames) {
+		return "unknown"
+	}
+	return classNames[c]
+}
+
+var classDefinitions = map[Class][]Property{
+	Equivalence:        {Reflexive, Symmetric, Transitive},
+	Tolerance:          {Reflexive, Symmetric},
+	Preorder:           {Reflexive, Transitive},
+	PartialOrder:       {Reflexive, Antisymmetric, Transitive},
+	StrictPartialOrder: {Irreflexive, Transitive},
+	WeakOrder:          {Reflexive, Complete, Transitive},
+	StrictWeakOrder:    {Asymmetric, NegativelyTransitive},
+	LinearOrder:        {Reflexive, Antisymmetric, Complete, Transitive},
+	StrictLinearOrder:  {Asymmetric, Complete, Transitive},
+	Tournament:         {Asymmetric, Complete},
+}
+
+func (c Class) Requires() []Property {
+	return classDefinitions[c]
+}
+
+// Classify returns every class the relation belongs to in declaration
+// order, nil for a ragged matrix.
+func Classify(a [][]bool) []Class {
+	r, err := Analyze(a)
+	if err != nil {
+		return nil
+	}
+	return r.Classes()
+}
+
+func (r Report) Classes() []Class {
+	res := []Class{}
+	for c := range Class(len(classNames)) {
+		if r.Has(classDefinitions[c]...) {
+			res = append(res, c)
+		}
+	}
+	return res
+}
@@ -0,0 +1,231 @@
+package binrels
+
+import "math/bits"
+
+const wordSize = 64
+
+// Relation is a square binary relation stored as packed uint64 row bitsets.
+// Row i occupies bits[i*stride : (i+1)*stride], bit j of the row is aRj.
+type Relation struct {
+	n      int
+	stride int
+	bits   []uint64
+}
+
+func words(n int) int {
+	return (n + wordSize - 1) / wordSize
+}
+
+func New(n int) *Relation {
+	if n < 0 {
+		n = 0
+	}
+	stride := words(n)
+	return &Relation{n: n, stride: stride, bits: make([]uint64, n*stride)}
+}
+
+func NewIdentity(n int) *Relation {
+	r := New(n)
+	for i := 0; i < n; i++ {
+		r.Set(i, i)
+	}
+	return r
+}
+
+// FromMatrix converts a square [][]bool; any other shape is an error.
+func FromMatrix(a [][]bool) (*Relation, error) {
+	if err := Validate(a); err != nil {
+		return nil, err
+	}
+	r := New(len(a))
+	for i := range a {
+		for j := range a[i] {
+			if a[i][j] {
+				r.Set(i, j)
+			}
+		}
+	}
+	return r, nil
+}
+
+func (r *Relation) Matrix() [][]bool {
+	m := Zero(r.n)
+	for i := 0; i < r.n; i++ {
+		for j := 0; j < r.n; j++ {
+			m[i][j] = r.Has(i, j)
+		}
+	}
+	return m
+}
+
+func (r *Relation) Size() int {
+	return r.n
+}
+
+func (r *Relation) row(i int) []uint64 {
+	return r.bits[i*r.stride : (i+1)*r.stride]
+}
+
+func (r *Relation) Has(i, j int) bool {
+	if i < 0 || j < 0 || i >= r.n || j >= r.n {
+		return false
+	}
+	return r.bits[i*r.stride+j/wordSize]&(1<<(uint(j)%wordSize)) != 0
+}
+
+func (r *Relation) Set(i, j int) {
+	if i < 0 || j < 0 || i >= r.n || j >= r.n {
+		return
+	}
+	r.bits[i*r.stride+j/wordSize] |= 1 << (uint(j) % wordSize)
+}
+
+func (r *Relation) Unset(i, j int) {
+	if i < 0 || j < 0 || i >= r.n || j >= r.n {
+		return
+	}
+	r.bits[i*r.stride+j/wordSize] &^= 1 << (uint(j) % wordSize)
+}
+
+// Row returns the indices j with iRj, i.e. the bottom intersection of i.
+func (r *Relation) Row(i int) []int {
+	if i < 0 || i >= r.n {
+		return nil
+	}
+	res := []int{}
+	for w, word := range r.row(i) {
+		for word != 0 {
+			res = append(res, w*wordSize+bits.TrailingZeros64(word))
+			word &= word - 1
+		}
+	}
+	return res
+}
+
+func (r *Relation) Count() int {
+	cnt := 0
+	for _, word := range r.bits {
+		cnt += bits.OnesCount64(word)
+	}
+	return cnt
+}
+
+func (r *Relation) Clone() *Relation {
+	c := &Relation{n: r.n, stride: r.stride, bits: make([]uint64, len(r.bits))}
+	copy(c.bits, r.bits)
+	return c
+}
+
+func (r *Relation) Equal(s *Relation) bool {
+	if r.n != s.n {
+		return false
+	}
+	for i := range r.bits {
+		if r.bits[i] != s.bits[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Relation) wordwise(s *Relation, f func(a, b uint64) uint64) *Relation {
+	if s == nil || r.n != s.n {
+		return nil
+	}
+	res := New(r.n)
+	for i := range r.bits {
+		res.bits[i] = f(r.bits[i], s.bits[i])
+	}
+	return res
+}
+
+func (r *Relation) Union(s *Relation) *Relation {
+	return r.wordwise(s, func(a, b uint64) uint64 { return a | b })
+}
+
+func (r *Relation) Intersection(s *Relation) *Relation {
+	return r.wordwise(s, func(a, b uint64) uint64 { return a & b })
+}
+
+func (r *Relation) Diff(s *Relation) *Relation {
+	return r.wordwise(s, func(a, b uint64) uint64 { return a &^ b })
+}
+
+func (r *Relation) SymmDiff(s *Relation) *Relation {
+	return r.wordwise(s, func(a, b uint64) uint64 { return a ^ b })
+}
+
+func (r *Relation) Complement() *Relation {
+	res := New(r.n)
+	for i := 0; i < r.n; i++ {
+		src, dst := r.row(i), res.row(i)
+		for w := range src {
+			dst[w] = ^src[w]
+		}
+		if rem := r.n % wordSize; rem != 0 {
+			dst[r.stride-1] &= 1<<uint(rem) - 1
+		}
+	}
+	return res
+}
+
+func (r *Relation) Transpose() *Relation {
+	res := New(r.n)
+	for i := 0; i < r.n; i++ {
+		for _, j := range r.Row(i) {
+			res.Set(j, i)
+		}
+	}
+	return res
+}
+
+// Composition returns r∘s: i(r∘s)j iff there is k with irk and ksj.
+// Every row of s reachable from row i of r is OR-ed in word by word.
+func (r *Relation) Composition(s *Relation) *Relation {
+	if s == nil || r.n != s.n {
+		return nil
+	}
+	res := New(r.n)
+	for i := 0; i < r.n; i++ {
+		dst := res.row(i)
+		for w, word := range r.row(i) {
+			for word != 0 {
+				k := w*wordSize + bits.TrailingZeros64(word)
+				word &= word - 1
+				for x, v := range s.row(k) {
+					dst[x] |= v
+				}
+			}
+		}
+	}
+	return res
+}
+
+// TransitiveClosure is Warshall's algorithm on bit rows: once k is allowed
+// as an intermediate vertex every row containing k absorbs row k.
+func (r *Relation) TransitiveClosure() *Relation {
+	res := r.Clone()
+	for k := 0; k < res.n; k++ {
+		rowK := res.row(k)
+		mask := uint64(1) << (uint(k) % wordSize)
+		for i := 0; i < res.n; i++ {
+			rowI := res.row(i)
+			if rowI[k/wordSize]&mask == 0 {
+				continue
+			}
+			for w := range rowI {
+				rowI[w] |= rowK[w]
+			}
+		}
+	}
+	return res
+}
+
+func (r *Relation) Reachability() *Relation {
+	return r.TransitiveClosure().Union(NewIdentity(r.n))
+}
+
+func (r *Relation) MutualReachability() *Relation {
+	reach := r.Reachability()
+	return reach.Intersection(reach.Transpose())
+}
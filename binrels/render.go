@@ -0,0 +1,91 @@
+package binrels
+
+import "decision-theory/graph"
+
+type RenderOptions struct {
+	Labels              []string
+	Layout              int
+	HighlightComponents bool
+	Width               int
+	Height              int
+}
+
+func (o RenderOptions) canvas(a [][]bool) *graph.Digraph {
+	w, h := o.Width, o.Height
+	if w <= 0 {
+		w = 800
+	}
+	if h <= 0 {
+		h = 600
+	}
+
+	labels := o.Labels
+	if len(labels) != len(a) {
+		labels = DefaultLabels(len(a))
+	}
+
+	d := graph.NewDigraph(w, h)
+	d.Nodes(labels)
+	d.Layout(o.Layout)
+	return d
+}
+
+// RenderPNG draws the relation with the graph package's own layout, without
+// relying on an external Graphviz installation.
+func RenderPNG(filename string, a [][]bool, opts RenderOptions) error {
+	d := opts.canvas(a)
+	for i := range a {
+		for j := range a[i] {
+			if a[i][j] {
+				d.Edge(i, j)
+			}
+		}
+	}
+
+	if opts.HighlightComponents {
+		groups := make([]int, len(a))
+		color := 0
+		for _, comp := range StronglyConnectedComponents(a) {
+			group := -1
+			if len(comp) > 1 || a[comp[0]][comp[0]] {
+				group = color
+				color++
+			}
+			for _, v := range comp {
+				groups[v] = group
+			}
+		}
+		d.Groups(groups)
+	}
+
+	if err := d.Draw(); err != nil {
+		return err
+	}
+	return d.SavePNG(filename)
+}
+
+// RenderHassePNG draws the Hasse diagram of a partial order, greater
+// elements on top.
+func RenderHassePNG(filename string, a [][]bool, opts RenderOptions) error {
+	h, err := Hasse(a)
+	if err != nil {
+		return err
+	}
+
+	opts.Layout = graph.LayeredLayout
+	d := opts.canvas(a)
+	d.BottomUp(true)
+	d.Arrows(false)
+	for i := range h {
+		for j := range h[i] {
+			if h[i][j] {
+				d.Edge(j, i)
+			}
+		}
+	}
+
+	if err := d.Draw(); err != nil {
+		return err
+	}
+	return d.SavePNG(filename)
+}
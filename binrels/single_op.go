@@ -19,8 +19,9 @@ func Power(a [][]bool, n int) [][]bool {
 	return Composition(a, Power(a, n-1))
 }
 
+// Transpose returns nil unless a is square; use Hetero for A×B relations.
 func Transpose(a [][]bool) [][]bool {
-	if len(a) == 0 {
+	if len(a) == 0 || Validate(a) != nil {
 		return nil
 	}
 
@@ -29,8 +30,9 @@ func Transpose(a [][]bool) [][]bool {
 	})
 }
 
+// Complement returns nil unless a is square; use Hetero for A×B relations.
 func Complement(a [][]bool) [][]bool {
-	if len(a) == 0 {
+	if len(a) == 0 || Validate(a) != nil {
 		return nil
 	}
 
@@ -100,23 +102,12 @@ func TopIntersection(a [][]bool, x int) []int {
 }
 
 func TransitiveClosure(a [][]bool) [][]bool {
-	if len(a) == 0 {
+	r, err := FromMatrix(a)
+	if err != nil || len(a) == 0 {
 		return nil
 	}
 
-	accumulator := Copy(a)
-	for i := 2; ; i++ {
-		next_a := Power(a, i)
-
-		before := Copy(accumulator)
-		accumulator = Union(accumulator, next_a)
-
-		if Equal(before, accumulator) {
-			break
-		}
-	}
-
-	return accumulator
+	return r.TransitiveClosure().Matrix()
 }
 
 func Reachability(a [][]bool) [][]bool {
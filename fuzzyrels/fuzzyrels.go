@@ -0,0 +1,311 @@
+package fuzzyrels
+
+import (
+	"decision-theory/binrels"
+	"fmt"
+	"math"
+)
+
+const eps = 1e-9
+
+func Zero(n int) [][]float64 {
+	matrix := make([][]float64, n)
+	for i := range matrix {
+		matrix[i] = make([]float64, n)
+	}
+	return matrix
+}
+
+func Identity(n int) [][]float64 {
+	matrix := Zero(n)
+	for i := range matrix {
+		matrix[i][i] = 1
+	}
+	return matrix
+}
+
+func Copy(a [][]float64) [][]float64 {
+	if len(a) == 0 {
+		return nil
+	}
+
+	return foreachcell(len(a), func(i, j int) float64 {
+		return a[i][j]
+	})
+}
+
+// FromFloat32 converts the float32 matrices produced by lab_5 grading.
+func FromFloat32(a [][]float32) [][]float64 {
+	if len(a) == 0 {
+		return nil
+	}
+
+	return foreachcell(len(a), func(i, j int) float64 {
+		return float64(a[i][j])
+	})
+}
+
+// Validate checks that the relation is square with memberships in [0, 1].
+func Validate(a [][]float64) error {
+	for i := range a {
+		if len(a[i]) != len(a) {
+			return fmt.Errorf("row %d has %d cells, expected %d", i, len(a[i]), len(a))
+		}
+		for j, v := range a[i] {
+			if math.IsNaN(v) || v < 0 || v > 1 {
+				return fmt.Errorf("membership (%d, %d) = %v is outside [0, 1]", i, j, v)
+			}
+		}
+	}
+	return nil
+}
+
+func Equal(a, b [][]float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return false
+		}
+
+		for j := range a[i] {
+			if math.Abs(a[i][j]-b[i][j]) > eps {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func foreachcell(size int, f func(i, j int) float64) [][]float64 {
+	result := Zero(size)
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			result[i][j] = f(i, j)
+		}
+	}
+	return result
+}
+
+func sameShape(a, b [][]float64) bool {
+	return len(a) == len(b) && len(a) != 0 && len(a[0]) == len(b[0])
+}
+
+func Union(a, b [][]float64) [][]float64 {
+	if !sameShape(a, b) {
+		return nil
+	}
+
+	return foreachcell(len(a), func(i, j int) float64 {
+		return math.Max(a[i][j], b[i][j])
+	})
+}
+
+func Intersection(a, b [][]float64) [][]float64 {
+	if !sameShape(a, b) {
+		return nil
+	}
+
+	return foreachcell(len(a), func(i, j int) float64 {
+		return math.Min(a[i][j], b[i][j])
+	})
+}
+
+func Complement(a [][]float64) [][]float64 {
+	if len(a) == 0 {
+		return nil
+	}
+
+	return foreachcell(len(a), func(i, j int) float64 {
+		return 1 - a[i][j]
+	})
+}
+
+func Transpose(a [][]float64) [][]float64 {
+	if len(a) == 0 {
+		return nil
+	}
+
+	return foreachcell(len(a), func(i, j int) float64 {
+		return a[j][i]
+	})
+}
+
+func compose(a, b [][]float64, tnorm func(x, y float64) float64) [][]float64 {
+	if !sameShape(a, b) {
+		return nil
+	}
+
+	return foreachcell(len(a), func(i, j int) float64 {
+		res := 0.0
+		for k := range a {
+			res = math.Max(res, tnorm(a[i][k], b[k][j]))
+		}
+		return res
+	})
+}
+
+// MaxMin is the standard composition: μ(x,z) = max_y min(μa(x,y), μb(y,z)).
+func MaxMin(a, b [][]float64) [][]float64 {
+	return compose(a, b, math.Min)
+}
+
+// MaxProduct composes with the algebraic product instead of min.
+func MaxProduct(a, b [][]float64) [][]float64 {
+	return compose(a, b, func(x, y float64) float64 { return x * y })
+}
+
+// AlphaCut returns the crisp relation {(x,y) : μ(x,y) >= alpha}, ready for
+// the binrels analysis and choice functions.
+func AlphaCut(a [][]float64, alpha float64) *binrels.Relation {
+	res := binrels.New(len(a))
+	for i := range a {
+		for j := range a[i] {
+			if a[i][j] >= alpha-eps {
+				res.Set(i, j)
+			}
+		}
+	}
+	return res
+}
+
+// StrictAlphaCut returns {(x,y) : μ(x,y) > alpha}.
+func StrictAlphaCut(a [][]float64, alpha float64) *binrels.Relation {
+	res := binrels.New(len(a))
+	for i := range a {
+		for j := range a[i] {
+			if a[i][j] > alpha+eps {
+				res.Set(i, j)
+			}
+		}
+	}
+	return res
+}
+
+// TransitiveClosure is the max-min transitive closure, computed with the
+// fuzzy analogue of Warshall's algorithm.
+func TransitiveClosure(a [][]float64) [][]float64 {
+	res := Copy(a)
+	for k := range res {
+		for i := range res {
+			if res[i][k] == 0 {
+				continue
+			}
+			for j := range res {
+				res[i][j] = math.Max(res[i][j], math.Min(res[i][k], res[k][j]))
+			}
+		}
+	}
+	return res
+}
+
+func IsReflexive(a [][]float64) bool {
+	for i := range a {
+		if a[i][i] < 1-eps {
+			return false
+		}
+	}
+	return true
+}
+
+func IsAntireflexive(a [][]float64) bool {
+	for i := range a {
+		if a[i][i] > eps {
+			return false
+		}
+	}
+	return true
+}
+
+func IsSymmetric(a [][]float64) bool {
+	for i := range a {
+		for j := range a[i] {
+			if math.Abs(a[i][j]-a[j][i]) > eps {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// IsAntisymmetric checks min(μ(x,y), μ(y,x)) = 0 for every x != y.
+func IsAntisymmetric(a [][]float64) bool {
+	for i := range a {
+		for j := range a[i] {
+			if i != j && math.Min(a[i][j], a[j][i]) > eps {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// IsTransitive checks max-min transitivity: μ(x,z) >= min(μ(x,y), μ(y,z)).
+func IsTransitive(a [][]float64) bool {
+	for i := range a {
+		for j := range a {
+			for k := range a {
+				if a[i][k] < math.Min(a[i][j], a[j][k])-eps {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// StrictPreference is Orlovsky's strict part: μs(x,y) = max(μ(x,y) - μ(y,x), 0).
+func StrictPreference(a [][]float64) [][]float64 {
+	if len(a) == 0 {
+		return nil
+	}
+
+	return foreachcell(len(a), func(i, j int) float64 {
+		return math.Max(a[i][j]-a[j][i], 0)
+	})
+}
+
+// NonDominance returns Orlovsky's membership of every alternative in the
+// fuzzy set of non-dominated alternatives: μND(x) = 1 - max_y μs(y,x).
+func NonDominance(a [][]float64) []float64 {
+	strict := StrictPreference(a)
+	res := make([]float64, len(a))
+	for x := range a {
+		dominated := 0.0
+		for y := range a {
+			dominated = math.Max(dominated, strict[y][x])
+		}
+		res[x] = 1 - dominated
+	}
+	return res
+}
+
+// Choose returns the alternatives with the greatest degree of
+// non-dominance. When that degree is 1 they are unfuzzy non-dominated.
+func Choose(a [][]float64) ([]int, float64) {
+	nd := NonDominance(a)
+	best := math.Inf(-1)
+	for _, v := range nd {
+		best = math.Max(best, v)
+	}
+
+	res := []int{}
+	for x, v := range nd {
+		if v >= best-eps {
+			res = append(res, x)
+		}
+	}
+	return res, best
+}
+
+func Print(a [][]float64) {
+	for i := range a {
+		for j := range a[i] {
+			fmt.Printf(" %.2f", a[i][j])
+		}
+		fmt.Println()
+	}
+}
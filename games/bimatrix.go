@@ -0,0 +1,137 @@
+package games
+
+import (
+	"slices"
+	"strconv"
+)
+
+// Bimatrix is a non-zero-sum game: when the row strategy i meets the column
+// strategy j the row player gets A[i][j] and the column player B[i][j].
+type Bimatrix struct {
+	Name        string
+	RowLabels   []string
+	ColLabels   []string
+	A           [][]int
+	B           [][]int
+	Description string
+}
+
+func (g Bimatrix) Rows() int {
+	return len(g.A)
+}
+
+func (g Bimatrix) Cols() int {
+	if len(g.A) == 0 {
+		return 0
+	}
+	return len(g.A[0])
+}
+
+func (g Bimatrix) RowLabel(i int) string {
+	if i >= 0 && i < len(g.RowLabels) {
+		return g.RowLabels[i]
+	}
+	return "row " + strconv.Itoa(i)
+}
+
+func (g Bimatrix) ColLabel(j int) string {
+	if j >= 0 && j < len(g.ColLabels) {
+		return g.ColLabels[j]
+	}
+	return "column " + strconv.Itoa(j)
+}
+
+// ZeroSum turns a matrix game into a bimatrix one with B = -A.
+func ZeroSum(g Game) Bimatrix {
+	a := make([][]int, len(g.Payoff))
+	b := make([][]int, len(g.Payoff))
+	for i, row := range g.Payoff {
+		a[i] = append([]int{}, row...)
+		b[i] = make([]int, len(row))
+		for j, v := range row {
+			b[i][j] = -v
+		}
+	}
+	return Bimatrix{
+		Name:        g.Name,
+		RowLabels:   slices.Clone(g.RowLabels),
+		ColLabels:   slices.Clone(g.ColLabels),
+		A:           a,
+		B:           b,
+		Description: g.Description,
+	}
+}
+
+// Chicken (Hawk-Dove), symmetric; payoffs to the row player:
+// H vs H = -2, H vs D = 2, D vs H = 0, D vs D = 1
+func ChickenDefault() Bimatrix {
+	return Bimatrix{
+		Name:      "Chicken (Hawk-Dove)",
+		RowLabels: []string{"Hawk", "Dove"},
+		ColLabels: []string{"Hawk", "Dove"},
+		A: [][]int{
+			{-2, 2},
+			{0, 1},
+		},
+		B: [][]int{
+			{-2, 0},
+			{2, 1},
+		},
+		Description: "two pure equilibria where one player yields and a mixed one",
+	}
+}
+
+func PrisonersDilemma() Bimatrix {
+	// Row/Col: Cooperate (C), Defect (D)
+	// R=3 (C,C), S=0 (C,D), T=5 (D,C), P=1 (D,D); B is the transpose of A
+	//   C    D
+	// C 3,3  0,5
+	// D 5,0  1,1
+	return Bimatrix{
+		Name:      "Prisoner's Dilemma",
+		RowLabels: []string{"Cooperate", "Defect"},
+		ColLabels: []string{"Cooperate", "Defect"},
+		A: [][]int{
+			{3, 0},
+			{5, 1},
+		},
+		B: [][]int{
+			{3, 5},
+			{0, 1},
+		},
+		Description: "mutual defection is the only equilibrium",
+	}
+}
+
+func BattleOfTheSexes() Bimatrix {
+	return Bimatrix{
+		Name:      "Battle of the Sexes",
+		RowLabels: []string{"Opera", "Football"},
+		ColLabels: []string{"Opera", "Football"},
+		A: [][]int{
+			{3, 0},
+			{0, 2},
+		},
+		B: [][]int{
+			{2, 0},
+			{0, 3},
+		},
+		Description: "two pure coordination equilibria and a mixed one",
+	}
+}
+
+// RowGame keeps a copy of the row player's payoffs, as if the game were
+// zero-sum.
+func (g Bimatrix) RowGame() Game {
+	payoff := make([][]int, len(g.A))
+	for i, row := range g.A {
+		payoff[i] = slices.Clone(row)
+	}
+	return Game{
+		Name:        g.Name,
+		RowLabels:   slices.Clone(g.RowLabels),
+		ColLabels:   slices.Clone(g.ColLabels),
+		Payoff:      payoff,
+		Description: "payoffs to the row player only",
+	}
+}
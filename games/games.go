@@ -1,60 +1,107 @@
 package games
 
-func CoinGameWith(same, diff int) [][]int {
-	return [][]int{
-		{same, diff},
-		{diff, same},
+import (
+	"fmt"
+	"strconv"
+)
+
+// Game is a matrix game: Payoff[i][j] is paid to the row player when the
+// row strategy i meets the column strategy j.
+type Game struct {
+	Name        string
+	RowLabels   []string
+	ColLabels   []string
+	Payoff      [][]int
+	Description string
+}
+
+func (g Game) Rows() int {
+	return len(g.Payoff)
+}
+
+func (g Game) Cols() int {
+	if len(g.Payoff) == 0 {
+		return 0
 	}
+	return len(g.Payoff[0])
 }
 
-func CoinGame() [][]int {
-	return CoinGameWith(2, -3)
+func (g Game) RowLabel(i int) string {
+	if i >= 0 && i < len(g.RowLabels) {
+		return g.RowLabels[i]
+	}
+	return "row " + strconv.Itoa(i)
 }
 
-// Chicken (Hawk-Dove) / payoff to row player
-// Numeric default Chicken (Hawk,Dove) with a negative HH entry:
-// H vs H = -2, H vs D = 2, D vs H = 0, D vs D = 1
-func ChickenDefault() [][]int {
-	return [][]int{
-		{-2, 2},
-		{0, 1},
+func (g Game) ColLabel(j int) string {
+	if j >= 0 && j < len(g.ColLabels) {
+		return g.ColLabels[j]
 	}
+	return "column " + strconv.Itoa(j)
 }
 
-func PrisonersDilemma() [][]int {
-	// Row/Col: Cooperate (C), Defect (D)
-	// Typical payoffs for row player: R=3 (C,C), S=0 (C,D), T=5 (D,C), P=1 (D,D)
-	// Matrix:
-	//   C  D
-	// C 3  0
-	// D 5  1
-	return [][]int{
-		{3, 0},
-		{5, 1},
+func numbered(prefix string, from, to int) []string {
+	labels := make([]string, 0, to-from+1)
+	for v := from; v <= to; v++ {
+		labels = append(labels, prefix+strconv.Itoa(v))
 	}
+	return labels
+}
+
+func CoinGameWith(same, diff int) Game {
+	return Game{
+		Name:      "Coin game",
+		RowLabels: []string{"Heads", "Tails"},
+		ColLabels: []string{"Heads", "Tails"},
+		Payoff: [][]int{
+			{same, diff},
+			{diff, same},
+		},
+		Description: fmt.Sprintf("row player wins %d when the coins match and %d otherwise", same, diff),
+	}
+}
+
+func CoinGame() Game {
+	return CoinGameWith(2, -3)
 }
 
-func Game2_s_vals() [][]int {
+func Game2_s_vals() Game {
 	// s,t in {-1,0,1}, payoff = s*(t-s) + t*(t+s)
 	values := []int{-1, 0, 1}
 	m := make([][]int, 3)
+	rows := make([]string, 3)
+	cols := make([]string, 3)
 	for i, s := range values {
 		row := make([]int, 3)
 		for j, t := range values {
 			row[j] = s*(t-s) + t*(t+s)
+			cols[j] = "t=" + strconv.Itoa(t)
 		}
 		m[i] = row
+		rows[i] = "s=" + strconv.Itoa(s)
+	}
+	return Game{
+		Name:        "Partners choosing values",
+		RowLabels:   rows,
+		ColLabels:   cols,
+		Payoff:      m,
+		Description: "s,t in {-1,0,1}, payoff = s*(t-s) + t*(t+s)",
 	}
-	return m
 }
 
 // 0 tie, +1 win for row, -1 loss
 // order: Rock, Paper, Scissors
-func RPS() [][]int {
-	return [][]int{
-		{0, -1, 1},
-		{1, 0, -1},
-		{-1, 1, 0},
+func RPS() Game {
+	labels := []string{"Rock", "Paper", "Scissors"}
+	return Game{
+		Name:      "Rock-Paper-Scissors",
+		RowLabels: labels,
+		ColLabels: labels,
+		Payoff: [][]int{
+			{0, -1, 1},
+			{1, 0, -1},
+			{-1, 1, 0},
+		},
 	}
 }
 
@@ -63,7 +110,7 @@ func RPS() [][]int {
 // if p1_guess == p2_show && p2_guess != p1_show -> + (p1_show + p2_show)
 // if p2_guess == p1_show && p1_guess != p2_show -> - (p1_show + p2_show)
 // if both guess correctly -> 0 (payments cancel)
-func Morra(fingers int) [][]int {
+func Morra(fingers int) Game {
 	strats := make([]int, fingers)
 	for i := 0; i < fingers; i++ {
 		strats[i] = i + 1
@@ -78,6 +125,13 @@ func Morra(fingers int) [][]int {
 		return (show-1)*fingers + (guess - 1)
 	}
 
+	labels := make([]string, n)
+	for _, show := range strats {
+		for _, guess := range strats {
+			labels[find_idx(show, guess)] = fmt.Sprintf("Show %d, guess %d", show, guess)
+		}
+	}
+
 	for _, p1_show := range strats {
 		for _, p1_guess := range strats {
 			for _, p2_show := range strats {
@@ -101,10 +155,16 @@ func Morra(fingers int) [][]int {
 			}
 		}
 	}
-	return m
+	return Game{
+		Name:        fmt.Sprintf("%d-finger Morra", fingers),
+		RowLabels:   labels,
+		ColLabels:   labels,
+		Payoff:      m,
+		Description: "each player shows fingers and guesses the opponent's; a lone correct guess wins the total shown",
+	}
 }
 
-func Game6(k int) [][]int {
+func Game6(k int) Game {
 	// choices 1..k, payoff: if i>=j -> i-j, else -> -(i+j)
 	m := make([][]int, k)
 	for i := 0; i < k; i++ {
@@ -119,18 +179,27 @@ func Game6(k int) [][]int {
 			}
 		}
 	}
-	return m
+	return Game{
+		Name:        fmt.Sprintf("Integers 1..%d", k),
+		RowLabels:   numbered("", 1, k),
+		ColLabels:   numbered("", 1, k),
+		Payoff:      m,
+		Description: "payoff i-j if i >= j, -(i+j) otherwise",
+	}
 }
 
-func Blotto(attacker, defender int) [][]int {
+func Blotto(attacker, defender int) Game {
 	// allocations between two positions. rows: attacker split a in [0..attacker] (a in pos1)
 	// columns: defender split d in [0..defender]
 	// attacker wins (payoff +1) if a>c OR (attacker_pos2 > defender_pos2), else payoff -1
 	rows := attacker + 1
 	cols := defender + 1
 	m := make([][]int, rows)
+	rowLabels := make([]string, rows)
+	colLabels := make([]string, cols)
 	for a := 0; a <= attacker; a++ {
 		m[a] = make([]int, cols)
+		rowLabels[a] = fmt.Sprintf("%d + %d", a, attacker-a)
 		for c := 0; c <= defender; c++ {
 			att_pos1 := a
 			att_pos2 := attacker - a
@@ -143,10 +212,19 @@ func Blotto(attacker, defender int) [][]int {
 			}
 		}
 	}
-	return m
+	for c := 0; c <= defender; c++ {
+		colLabels[c] = fmt.Sprintf("%d + %d", c, defender-c)
+	}
+	return Game{
+		Name:        fmt.Sprintf("Colonel Blotto (attacker=%d, defender=%d)", attacker, defender),
+		RowLabels:   rowLabels,
+		ColLabels:   colLabels,
+		Payoff:      m,
+		Description: "strategies split units as position 1 + position 2",
+	}
 }
 
-func SellerProblem(k, a, b, alpha, beta int) [][]int {
+func SellerProblem(k, a, b, alpha, beta int) Game {
 	// seller chooses s in [0..k]; demand d in [alpha..beta]
 	// payoff = a*min(s,d) - b*max(0, s-d)
 	rows := k + 1
@@ -163,5 +241,11 @@ func SellerProblem(k, a, b, alpha, beta int) [][]int {
 			}
 		}
 	}
-	return m
+	return Game{
+		Name:        "Seller problem",
+		RowLabels:   numbered("Stock ", 0, k),
+		ColLabels:   numbered("Demand ", alpha, beta),
+		Payoff:      m,
+		Description: fmt.Sprintf("payoff = %d*min(s,d) - %d*max(0, s-d)", a, b),
+	}
 }
@@ -0,0 +1,29 @@
+package games
+
+import "fmt"
+
+// ToFloat converts an integer payoff matrix for the solvers.
+func ToFloat(payoff [][]int) [][]float64 {
+	m := make([][]float64, len(payoff))
+	for i := range payoff {
+		m[i] = make([]float64, len(payoff[i]))
+		for j := range payoff[i] {
+			m[i][j] = float64(payoff[i][j])
+		}
+	}
+	return m
+}
+
+// ValidatePayoff reports an error unless payoff is a non-empty rectangular
+// matrix.
+func ValidatePayoff(payoff [][]float64) error {
+	if len(payoff) == 0 || len(payoff[0]) == 0 {
+		return fmt.Errorf("empty payoff matrix")
+	}
+	for i := range payoff {
+		if len(payoff[i]) != len(payoff[0]) {
+			return fmt.Errorf("row %d has %d columns, expected %d", i, len(payoff[i]), len(payoff[0]))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,328 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/fogleman/gg"
+)
+
+const (
+	LayeredLayout = iota
+	CircularLayout
+)
+
+// Digraph draws a directed graph with labelled nodes. Node positions come
+// from a layered (Sugiyama-style) or circular layout, so no external
+// Graphviz installation is needed.
+type Digraph struct {
+	dc       *gg.Context
+	width    int
+	height   int
+	labels   []string
+	edges    [][2]int
+	groups   []int
+	layout   int
+	bottomUp bool
+	arrows   bool
+}
+
+func NewDigraph(w, h int) *Digraph {
+	dc := gg.NewContext(w, h)
+	dc.SetRGB(1, 1, 1)
+	dc.Clear()
+
+	return &Digraph{dc: dc, width: w, height: h, layout: LayeredLayout, arrows: true}
+}
+
+func (d *Digraph) Nodes(labels []string) {
+	d.labels = labels
+	d.groups = nil
+	d.edges = d.edges[:0]
+}
+
+func (d *Digraph) Edge(from, to int) {
+	if from < 0 || to < 0 || from >= len(d.labels) || to >= len(d.labels) {
+		return
+	}
+	d.edges = append(d.edges, [2]int{from, to})
+}
+
+// Groups colours nodes by group id; nodes with a negative id stay uncoloured.
+func (d *Digraph) Groups(groups []int) {
+	if len(groups) != len(d.labels) {
+		return
+	}
+	d.groups = groups
+}
+
+func (d *Digraph) Layout(layout int) {
+	d.layout = layout
+}
+
+// BottomUp places sources at the bottom, as in Hasse diagrams.
+func (d *Digraph) BottomUp(b bool) {
+	d.bottomUp = b
+}
+
+func (d *Digraph) Arrows(b bool) {
+	d.arrows = b
+}
+
+func (d *Digraph) SavePNG(filename string, replace ...bool) error {
+	return savePNG(d.dc, filename, replace...)
+}
+
+// layers assigns every node the length of the longest path reaching it,
+// ignoring loops and DFS back edges so cyclic graphs still get a layering.
+func (d *Digraph) layers() []int {
+	n := len(d.labels)
+	adj := make([][]int, n)
+	for _, e := range d.edges {
+		if e[0] != e[1] {
+			adj[e[0]] = append(adj[e[0]], e[1])
+		}
+	}
+
+	state := make([]int, n)
+	forward := make([][]int, n)
+	var visit func(v int)
+	visit = func(v int) {
+		state[v] = 1
+		for _, u := range adj[v] {
+			if state[u] == 1 {
+				continue
+			}
+			forward[v] = append(forward[v], u)
+			if state[u] == 0 {
+				visit(u)
+			}
+		}
+		state[v] = 2
+	}
+	for v := 0; v < n; v++ {
+		if state[v] == 0 {
+			visit(v)
+		}
+	}
+
+	indegree := make([]int, n)
+	for v := range forward {
+		for _, u := range forward[v] {
+			indegree[u]++
+		}
+	}
+	queue := []int{}
+	for v := 0; v < n; v++ {
+		if indegree[v] == 0 {
+			queue = append(queue, v)
+		}
+	}
+	layer := make([]int, n)
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, u := range forward[v] {
+			layer[u] = max(layer[u], layer[v]+1)
+			indegree[u]--
+			if indegree[u] == 0 {
+				queue = append(queue, u)
+			}
+		}
+	}
+	return layer
+}
+
+func (d *Digraph) layeredPositions(margin float64) ([]float64, []float64) {
+	n := len(d.labels)
+	layer := d.layers()
+
+	depth := 0
+	for _, l := range layer {
+		depth = max(depth, l+1)
+	}
+	rows := make([][]int, depth)
+	for v := 0; v < n; v++ {
+		rows[layer[v]] = append(rows[layer[v]], v)
+	}
+
+	neighbours := make([][]int, n)
+	for _, e := range d.edges {
+		if e[0] != e[1] {
+			neighbours[e[0]] = append(neighbours[e[0]], e[1])
+			neighbours[e[1]] = append(neighbours[e[1]], e[0])
+		}
+	}
+
+	// barycenter heuristic: a few alternating sweeps to reduce crossings
+	pos := make([]float64, n)
+	for _, row := range rows {
+		for i, v := range row {
+			pos[v] = float64(i)
+		}
+	}
+	reorder := func(l, ref int) {
+		bary := make(map[int]float64, len(rows[l]))
+		for _, v := range rows[l] {
+			sum, cnt := 0.0, 0
+			for _, u := range neighbours[v] {
+				if layer[u] == ref {
+					sum += pos[u]
+					cnt++
+				}
+			}
+			if cnt == 0 {
+				bary[v] = pos[v]
+			} else {
+				bary[v] = sum / float64(cnt)
+			}
+		}
+		sort.SliceStable(rows[l], func(i, j int) bool {
+			return bary[rows[l][i]] < bary[rows[l][j]]
+		})
+		for i, v := range rows[l] {
+			pos[v] = float64(i)
+		}
+	}
+	for sweep := 0; sweep < 4; sweep++ {
+		for l := 1; l < depth; l++ {
+			reorder(l, l-1)
+		}
+		for l := depth - 2; l >= 0; l-- {
+			reorder(l, l+1)
+		}
+	}
+
+	xs := make([]float64, n)
+	ys := make([]float64, n)
+	w := float64(d.width) - 2*margin
+	h := float64(d.height) - 2*margin
+	for l, row := range rows {
+		y := float64(d.height) / 2
+		if depth > 1 {
+			y = margin + h*float64(l)/float64(depth-1)
+		}
+		if d.bottomUp {
+			y = float64(d.height) - y
+		}
+		for i, v := range row {
+			xs[v] = margin + w*(float64(i)+0.5)/float64(len(row))
+			ys[v] = y
+		}
+	}
+	return xs, ys
+}
+
+func (d *Digraph) circularPositions(margin float64) ([]float64, []float64) {
+	n := len(d.labels)
+	xs := make([]float64, n)
+	ys := make([]float64, n)
+	cx, cy := float64(d.width)/2, float64(d.height)/2
+	r := math.Min(cx, cy) - margin
+	for v := 0; v < n; v++ {
+		angle := -math.Pi/2 + 2*math.Pi*float64(v)/float64(n)
+		xs[v] = cx + r*math.Cos(angle)
+		ys[v] = cy + r*math.Sin(angle)
+	}
+	return xs, ys
+}
+
+func (d *Digraph) drawArrowHead(x, y, angle float64) {
+	const size = 10.0
+	d.dc.MoveTo(x, y)
+	d.dc.LineTo(x-size*math.Cos(angle-0.4), y-size*math.Sin(angle-0.4))
+	d.dc.LineTo(x-size*math.Cos(angle+0.4), y-size*math.Sin(angle+0.4))
+	d.dc.ClosePath()
+	d.dc.Fill()
+}
+
+func (d *Digraph) drawEdge(x1, y1, x2, y2, radius float64, curved bool) {
+	angle := math.Atan2(y2-y1, x2-x1)
+	sx, sy := x1+radius*math.Cos(angle), y1+radius*math.Sin(angle)
+	ex, ey := x2-radius*math.Cos(angle), y2-radius*math.Sin(angle)
+
+	if !curved {
+		d.dc.DrawLine(sx, sy, ex, ey)
+		d.dc.Stroke()
+		if d.arrows {
+			d.drawArrowHead(ex, ey, angle)
+		}
+		return
+	}
+
+	// opposite edges bend to different sides of the straight line
+	mx, my := (sx+ex)/2, (sy+ey)/2
+	bend := 0.2 * math.Hypot(ex-sx, ey-sy)
+	cx, cy := mx+bend*math.Sin(angle), my-bend*math.Cos(angle)
+	d.dc.MoveTo(sx, sy)
+	d.dc.QuadraticTo(cx, cy, ex, ey)
+	d.dc.Stroke()
+	if d.arrows {
+		d.drawArrowHead(ex, ey, math.Atan2(ey-cy, ex-cx))
+	}
+}
+
+func (d *Digraph) drawLoop(x, y, radius float64) {
+	cy := y - radius - radius/2
+	d.dc.DrawCircle(x, cy, radius/2)
+	d.dc.Stroke()
+	if d.arrows {
+		d.drawArrowHead(x+radius/2, cy+radius/2, math.Pi/2)
+	}
+}
+
+func (d *Digraph) Draw() error {
+	if len(d.labels) == 0 {
+		return fmt.Errorf("no nodes to draw")
+	}
+
+	font, err := GetFontFace(14)
+	if err != nil {
+		return err
+	}
+	d.dc.SetFontFace(font)
+
+	const radius = 22.0
+	margin := 3 * radius
+
+	var xs, ys []float64
+	switch d.layout {
+	case CircularLayout:
+		xs, ys = d.circularPositions(margin)
+	default:
+		xs, ys = d.layeredPositions(margin)
+	}
+
+	has := make(map[[2]int]bool, len(d.edges))
+	for _, e := range d.edges {
+		has[e] = true
+	}
+
+	d.dc.SetRGB(0.2, 0.2, 0.2)
+	d.dc.SetLineWidth(1.5)
+	for _, e := range d.edges {
+		from, to := e[0], e[1]
+		if from == to {
+			d.drawLoop(xs[from], ys[from], radius)
+			continue
+		}
+		d.drawEdge(xs[from], ys[from], xs[to], ys[to], radius, has[[2]int{to, from}])
+	}
+
+	for v := range d.labels {
+		d.dc.DrawCircle(xs[v], ys[v], radius)
+		if d.groups != nil && d.groups[v] >= 0 {
+			c := plotColors[d.groups[v]%len(plotColors)]
+			d.dc.SetRGB(0.65+0.35*c[0], 0.65+0.35*c[1], 0.65+0.35*c[2])
+		} else {
+			d.dc.SetRGB(1, 1, 1)
+		}
+		d.dc.FillPreserve()
+		d.dc.SetRGB(0, 0, 0)
+		d.dc.Stroke()
+		d.dc.DrawStringAnchored(d.labels[v], xs[v], ys[v], 0.5, 0.35)
+	}
+
+	return nil
+}
@@ -178,6 +178,7 @@ func (g *Graph) Draw() error {
 	switch g.gtype {
 	case GraphType:
 		g.drawPlots(scaleX, scaleY, offsetX, offsetY, originY)
+		g.drawLegend(offsetX+plotWidth, offsetY)
 	case HeatmapType:
 		g.drawHeatmap(scaleX, scaleY, plotHeight, plotWidth, offsetX, offsetY)
 	}
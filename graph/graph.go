@@ -78,6 +78,10 @@ func (g *Graph) ClearWithNewSize(w, h int) {
 }
 
 func (g *Graph) SavePNG(filename string, replace ...bool) error {
+	return savePNG(g.dc, filename, replace...)
+}
+
+func savePNG(dc *gg.Context, filename string, replace ...bool) error {
 	name := strings.TrimSuffix(filename, ".png")
 	ext := ".png"
 	if len(replace) > 0 && !replace[0] {
@@ -91,7 +95,7 @@ func (g *Graph) SavePNG(filename string, replace ...bool) error {
 		}
 	}
 
-	return g.dc.SavePNG(name + ext)
+	return dc.SavePNG(name + ext)
 }
 
 func (g *Graph) getFlattenedData() ([]float64, []float64) {
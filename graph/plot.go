@@ -7,6 +7,7 @@ type Plot struct {
 	y      []float64
 	labels []string
 	ls     *LineStyle
+	name   string
 }
 
 func (g *Graph) Plot(x, y []float64, ls *LineStyle, labels ...[]string) {
@@ -72,3 +73,53 @@ func (g *Graph) drawPlots(scaleX, scaleY, offsetX, offsetY, originY float64) {
 
 	g.dc.Stroke()
 }
+
+// Name sets the legend entry of the plot added last. The legend box is
+// drawn only when some plot has a name.
+func (g *Graph) Name(name string) {
+	if len(g.plots) > 0 {
+		g.plots[len(g.plots)-1].name = name
+	}
+}
+
+func (g *Graph) drawLegend(right, top float64) {
+	rows := 0
+	width := 0.0
+	for _, p := range g.plots {
+		if p.name != "" {
+			w, _ := g.dc.MeasureString(p.name)
+			width = max(width, w)
+			rows++
+		}
+	}
+	if rows == 0 {
+		return
+	}
+
+	const sample, gap, lineHeight = 24.0, 8.0, 20.0
+	boxW := sample + 3*gap + width
+	boxH := float64(rows)*lineHeight + gap
+	x, y := right-boxW-gap, top+gap
+
+	g.dc.SetRGB(1, 1, 1)
+	g.dc.DrawRectangle(x, y, boxW, boxH)
+	g.dc.FillPreserve()
+	g.dc.SetRGB(0, 0, 0)
+	g.dc.SetLineWidth(1)
+	g.dc.Stroke()
+
+	row := 0
+	for i, p := range g.plots {
+		if p.name == "" {
+			continue
+		}
+		cy := y + gap/2 + lineHeight*(float64(row)+0.5)
+		color := plotColors[i%len(plotColors)]
+		g.dc.SetRGB(color[0], color[1], color[2])
+		p.ls.SetLineParams(g.dc)
+		p.ls.DrawLine(g.dc, []float64{x + gap, x + gap + sample/2, x + gap + sample}, []float64{cy, cy, cy}, cy)
+		g.dc.SetRGB(0, 0, 0)
+		g.dc.DrawStringAnchored(p.name, x+2*gap+sample, cy, 0, 0.35)
+		row++
+	}
+}
@@ -4,96 +4,98 @@ import (
 	"fmt"
 
 	"decision-theory/games"
+	"decision-theory/nash"
+	"decision-theory/uncertainty"
+	"decision-theory/zerosum"
 )
 
-func saddlePoint(m [][]int) (bool, int, [][2]int) {
-	// empty matrix
+func printResult(name string, g games.Game) {
+	m := g.Payoff
 	if len(m) == 0 || len(m[0]) == 0 {
-		return false, 0, nil
+		fmt.Println("No valid matrix provided.")
+		return
 	}
 
-	rows := len(m)
-	cols := len(m[0])
+	ok, val, pos := zerosum.SaddlePoint(m)
+	fmt.Println("----", name, "----")
+	fmt.Printf("Matrix %dx%d\n", len(m), len(m[0]))
 
-	// compute row minima
-	rowMins := make([]int, rows)
-	for i := 0; i < rows; i++ {
-		min := m[i][0]
-		for j := 1; j < cols; j++ {
-			if m[i][j] < min {
-				min = m[i][j]
-			}
+	if !ok {
+		fmt.Println("No saddle point (no pure-strategy equilibrium).")
+	} else {
+		fmt.Printf("Saddle point(s) found. Game value (payoff to row player) = %d\n", val)
+		fmt.Println("Equilibrium positions (row, column) — zero-based indices, strategies and payoffs:")
+		for _, p := range pos {
+			i, j := p[0], p[1]
+			fmt.Printf("(%d, %d)  %s vs %s  payoff = %d\n", i, j, g.RowLabel(i), g.ColLabel(j), m[i][j])
 		}
-		rowMins[i] = min
 	}
+	fmt.Println()
+}
 
-	// compute max of row minima (maximin)
-	maxOfRowMins := rowMins[0]
-	for i := 1; i < rows; i++ {
-		if rowMins[i] > maxOfRowMins {
-			maxOfRowMins = rowMins[i]
-		}
+// printNash lists every Nash equilibrium of a non-zero-sum game
+func printNash(name string, g games.Bimatrix) {
+	fmt.Println("----", name, "----")
+	res, err := nash.Solve(g)
+	if err != nil {
+		fmt.Println("Failed to solve game:", err)
+		return
 	}
-
-	// compute column maxima (fixed loop)
-	colMaxs := make([]int, cols)
-	for j := 0; j < cols; j++ {
-		max := m[0][j]
-		for i := 1; i < rows; i++ {
-			if m[i][j] > max {
-				max = m[i][j]
-			}
-		}
-		colMaxs[j] = max
+	if res.Degenerate {
+		fmt.Println("The game is degenerate, equilibria may not be isolated.")
 	}
 
-	// compute min of column maxima (minimax)
-	minOfColMaxs := colMaxs[0]
-	for j := 1; j < cols; j++ {
-		if colMaxs[j] < minOfColMaxs {
-			minOfColMaxs = colMaxs[j]
+	for _, e := range res.Equilibria {
+		if e.Pure() {
+			rows, cols := e.Supports()
+			fmt.Printf("pure:  %s vs %s  payoffs = (%.4f, %.4f)\n", g.RowLabel(rows[0]), g.ColLabel(cols[0]), e.RowPayoff, e.ColPayoff)
+		} else {
+			fmt.Println("mixed:", e)
 		}
 	}
+	fmt.Println()
+}
 
-	// saddle exists only if maximin == minimax
-	if maxOfRowMins != minOfColMaxs {
-		return false, 0, nil
+// printUncertainty treats the columns as states of nature and applies the
+// decision criteria to the row player's payoffs
+func printUncertainty(name string, g games.Game, pessimism float64, probs []float64) {
+	fmt.Println("----", name, "----")
+	payoff := games.ToFloat(g.Payoff)
+	report, err := uncertainty.Analyze(payoff, pessimism, probs)
+	if err != nil {
+		fmt.Println("Failed to analyse:", err)
+		return
 	}
 
-	value := maxOfRowMins
-	positions := make([][2]int, 0)
-
-	// collect all positions that are equilibrium (value, row-min and col-max)
-	for i := 0; i < rows; i++ {
-		for j := 0; j < cols; j++ {
-			if m[i][j] == value && m[i][j] == rowMins[i] && m[i][j] == colMaxs[j] {
-				positions = append(positions, [2]int{i, j})
-			}
+	fmt.Println("Regret matrix:")
+	for i, row := range report.Regret {
+		fmt.Printf("%-10s", g.RowLabel(i))
+		for _, v := range row {
+			fmt.Printf("%6.1f", v)
 		}
+		fmt.Println()
 	}
 
-	return true, value, positions
-}
+	for _, d := range report.Decisions {
+		fmt.Printf("%-24s value = %7.2f  choice:", d.Criterion, d.Value)
+		for _, i := range d.Best {
+			fmt.Printf(" %s", g.RowLabel(i))
+		}
+		fmt.Println()
+	}
 
-func printResult(name string, m [][]int) {
-	if len(m) == 0 || len(m[0]) == 0 {
-		fmt.Println("No valid matrix provided.")
+	curve, err := uncertainty.HurwiczCurve(payoff)
+	if err != nil {
+		fmt.Println("Failed to build Hurwicz curve:", err)
 		return
 	}
-
-	ok, val, pos := saddlePoint(m)
-	fmt.Println("----", name, "----")
-	fmt.Printf("Matrix %dx%d\n", len(m), len(m[0]))
-
-	if !ok {
-		fmt.Println("No saddle point (no pure-strategy equilibrium).")
-	} else {
-		fmt.Printf("Saddle point(s) found. Game value (payoff to row player) = %d\n", val)
-		fmt.Println("Equilibrium positions (row, column) — zero-based indices and payoffs:")
-		for _, p := range pos {
-			i, j := p[0], p[1]
-			fmt.Printf("(%d, %d)  payoff = %d\n", i, j, m[i][j])
+	fmt.Println("Hurwicz choice by pessimism coefficient:")
+	for _, seg := range curve {
+		fmt.Printf("  [%.3f, %.3f]:", seg.From, seg.To)
+		for _, i := range seg.Best {
+			fmt.Printf(" %s", g.RowLabel(i))
 		}
+		fmt.Println()
 	}
 	fmt.Println()
 }
@@ -107,8 +109,12 @@ func main() {
 	printResult("6. Integers 1..k (k=4)", games.Game6(4))
 	printResult("7. Colonel Blotto (attacker=3, defender=3)", games.Blotto(3, 3))
 	printResult("8. Seller problem (k=5,a=10,b=4,alpha=0,beta=5)", games.SellerProblem(5, 10, 4, 0, 5))
-	printResult("custom 1. Prisoners Dilemma", games.PrisonersDilemma())
-	printResult("custom 2. Chicken(Hawk-Dove)", games.ChickenDefault())
+	printNash("custom 1. Prisoners Dilemma", games.PrisonersDilemma())
+	printNash("custom 2. Chicken(Hawk-Dove)", games.ChickenDefault())
+	printNash("custom 3. Battle of the Sexes", games.BattleOfTheSexes())
+
+	printResult("Test Matrix with 2 saddle points", games.Game{Payoff: [][]int{{1, 2}, {1, 2}}})
 
-	printResult("Test Matrix with 2 saddle points", [][]int{{1, 2}, {1, 2}})
+	printUncertainty("Seller problem against nature (pessimism 0.6)", games.SellerProblem(5, 10, 4, 0, 5), 0.6,
+		[]float64{0.05, 0.1, 0.2, 0.3, 0.2, 0.15})
 }
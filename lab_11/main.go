@@ -2,265 +2,349 @@ package main
 
 import (
 	"decision-theory/games"
+	"decision-theory/graph"
 	"decision-theory/lab_11/matrix"
+	"decision-theory/lp"
+	"decision-theory/uncertainty"
+	"decision-theory/zerosum"
 	"fmt"
-
-	"github.com/willauld/lpsimplex"
 )
 
-// Vector represents a 1D vector
-type Vector []float64
-
-// GameResult contains the solution of a Matrix game
-type GameResult struct {
-	PlayerXStrategy Vector
-	PlayerYStrategy Vector
-	GameValue       float64
-}
-
-// SolveMatrixGame solves a Matrix game using the generalized algorithm
-func SolveMatrixGame(game matrix.Matrix) GameResult {
+// SolveMatrixGame solves a Matrix game and reports the result
+func SolveMatrixGame(game matrix.Matrix) (zerosum.Solution, error) {
 	fmt.Println("=== Solving Matrix Game ===")
 	game.Print("Original Game Matrix:")
 
-	// Step 1: Make Matrix strictly positive
-	positiveMatrix, shift := game.MakePositive()
-	positiveMatrix.Print("Positive Matrix:")
-
-	rows := len(positiveMatrix)
-	cols := len(positiveMatrix[0])
-
-	// Step 2: Solve LP for player 1 (minimization)
-	x := solvePlayerOneProblem(positiveMatrix, rows, cols)
-
-	// Step 3: Solve LP for player 2 (maximization)
-	y := solvePlayerTwoProblem(positiveMatrix, rows, cols)
+	result, err := zerosum.Solve(game)
+	if err != nil {
+		return result, err
+	}
 
-	// Step 4: Normalize strategies and calculate game value
-	thetaX := sum(x)
-	phiY := sum(y)
+	if result.Saddle {
+		fmt.Printf("Saddle point(s) found: %v\n\n", result.SaddlePoints)
+	}
+	return result, nil
+}
 
-	xStar := make(Vector, rows)
-	for i := range x {
-		if thetaX != 0 {
-			xStar[i] = x[i] / thetaX
-		} else {
-			xStar[i] = 0
+// PrintResult prints the game result; strategies with a positive
+// probability are also listed by their labels when the game has them
+func PrintResult(result zerosum.Solution, game ...games.Game) {
+	fmt.Println("=== Game Solution ===")
+	fmt.Printf("Player 1 Optimal Strategy: ")
+	for i, val := range result.RowStrategy {
+		fmt.Printf("x%d=%.4f ", i+1, val)
+	}
+	fmt.Println()
+	if len(game) > 0 {
+		for i, val := range result.RowStrategy {
+			if val > 1e-9 {
+				fmt.Printf("  %s: %.4f\n", game[0].RowLabel(i), val)
+			}
 		}
 	}
 
-	yStar := make(Vector, cols)
-	for i := range y {
-		if phiY != 0 {
-			yStar[i] = y[i] / phiY
-		} else {
-			yStar[i] = 0
+	fmt.Printf("Player 2 Optimal Strategy: ")
+	for i, val := range result.ColStrategy {
+		fmt.Printf("y%d=%.4f ", i+1, val)
+	}
+	fmt.Println()
+	if len(game) > 0 {
+		for j, val := range result.ColStrategy {
+			if val > 1e-9 {
+				fmt.Printf("  %s: %.4f\n", game[0].ColLabel(j), val)
+			}
 		}
 	}
 
-	// Game value for positive Matrix
-	gameValuePositive := 1.0
-	if thetaX != 0 {
-		gameValuePositive = 1.0 / thetaX
-	}
+	fmt.Printf("Game Value: %.4f\n", result.Value)
+	fmt.Println()
+}
 
-	// Game value for original Matrix
-	gameValue := gameValuePositive - shift
+func ExampleFromLab() matrix.Matrix {
+	return matrix.Matrix{
+		{1, 2, -2},
+		{-1, 0, 1},
+		{1, 1, -1},
+	}
+}
 
-	fmt.Printf("Sum of x (θ): %.6f\n", thetaX)
-	fmt.Printf("Sum of y (φ): %.6f\n", phiY)
-	fmt.Printf("Game value (positive Matrix): %.6f\n", gameValuePositive)
-	fmt.Printf("Game value (original Matrix): %.6f\n\n", gameValue)
+func ToMatrix(g games.Game) matrix.Matrix {
+	return matrix.NewMatrix(games.ToFloat(g.Payoff))
+}
 
-	return GameResult{
-		PlayerXStrategy: xStar,
-		PlayerYStrategy: yStar,
-		GameValue:       gameValue,
+func solveAndPrint(title string, m matrix.Matrix, game ...games.Game) {
+	fmt.Printf("\n### %s ###\n", title)
+	result, err := SolveMatrixGame(m)
+	if err != nil {
+		fmt.Println("Failed to solve game:", err)
+		return
 	}
+	PrintResult(result, game...)
 }
 
-// solvePlayerOneProblem solves the LP for player 1
-func solvePlayerOneProblem(A matrix.Matrix, rows, cols int) Vector {
-	// Primal (player 1): minimize sum(x_i) subject to A^T * x >= 1, x >= 0
-	// Convert constraints into Aub * x <= bub by negation: (-A^T) x <= -1
-	n := rows
-	m := cols
-
-	c := make([]float64, n)
-	for i := 0; i < n; i++ {
-		c[i] = 1.0
-	}
-
-	// build Aub = -A^T and bub = -1
-	Aub := make([][]float64, m)
-	bub := make([]float64, m)
-	for j := 0; j < m; j++ {
-		Aub[j] = make([]float64, n)
-		for i := 0; i < n; i++ {
-			Aub[j][i] = -A[i][j]
-		}
-		bub[j] = -1.0
+// reduceAndPrint removes dominated strategies before solving and prints
+// every elimination step
+func reduceAndPrint(title string, game games.Game, opts zerosum.DominanceOptions) {
+	fmt.Printf("\n### %s ###\n", title)
+	result, red, err := zerosum.SolveReduced(games.ToFloat(game.Payoff), opts)
+	if err != nil {
+		fmt.Println("Failed to solve game:", err)
+		return
 	}
 
-	// Solve (minimize)
-	xsol, err := SolveLP(c, Aub, bub, false)
+	fmt.Println("=== Dominance Elimination ===")
+	for _, step := range red.Steps {
+		fmt.Println(step)
+	}
+	fmt.Printf("Reduced to %dx%d (rows %v, columns %v)\n\n", len(red.Rows), len(red.Cols), red.Rows, red.Cols)
+	PrintResult(result, game)
+}
+
+// approximateAndPrint runs fictitious play, compares it with the LP value
+// and saves the convergence curve of the value bounds
+func approximateAndPrint(title string, game games.Game, eps float64, filename string) {
+	fmt.Printf("\n### %s ###\n", title)
+	payoff := games.ToFloat(game.Payoff)
+	fp, err := zerosum.BrownRobinson(payoff, eps, 100000)
 	if err != nil {
-		fmt.Printf("Player 1 LP failed: %v\n", err)
-		return make(Vector, rows)
+		fmt.Println("Failed to approximate game:", err)
+		return
 	}
 
-	x := make(Vector, rows)
-	for i := 0; i < rows; i++ {
-		x[i] = xsol[i]
+	fmt.Println("=== Brown-Robinson Method ===")
+	fmt.Printf("Iterations: %d (converged: %v)\n", len(fp.History), fp.Converged)
+	fmt.Printf("Value bounds: [%.4f, %.4f]\n", fp.Lower, fp.Upper)
+	if exact, err := zerosum.Solve(payoff); err == nil {
+		fmt.Printf("LP value: %.4f\n", exact.Value)
 	}
-	return x
-}
+	PrintResult(zerosum.Solution{RowStrategy: fp.RowStrategy, ColStrategy: fp.ColStrategy, Value: fp.Value}, game)
 
-// solvePlayerTwoProblem solves the LP for player 2
-func solvePlayerTwoProblem(A matrix.Matrix, rows, cols int) Vector {
-	// Dual (player 2): maximize sum(y_j) subject to A * y <= 1, y >= 0
-	n := cols
-	m := rows
-
-	// objective c (maximize): pass positive c and set maximize=true
-	c := make([]float64, n)
-	for j := 0; j < n; j++ {
-		c[j] = 1.0
-	}
-
-	// Aub is A (<= constraints), bub is ones
-	Aub := make([][]float64, m)
-	bub := make([]float64, m)
-	for i := 0; i < m; i++ {
-		Aub[i] = make([]float64, n)
-		for j := 0; j < n; j++ {
-			Aub[i][j] = A[i][j]
-		}
-		bub[i] = 1.0
+	g := graph.NewGraph(800, 400)
+	fp.Plot(g)
+	if err := g.Draw(); err != nil {
+		fmt.Println("Failed to draw convergence:", err)
+		return
+	}
+	if err := g.SavePNG(filename); err != nil {
+		fmt.Println("Failed to save convergence:", err)
 	}
+}
 
-	// Solve (maximize)
-	ysol, err := SolveLP(c, Aub, bub, true)
+// graphicalAndPrint solves a 2xn or mx2 game by the envelope of lines and
+// saves the picture
+func graphicalAndPrint(title string, game games.Game, filename string) {
+	fmt.Printf("\n### %s ###\n", title)
+	sol, err := zerosum.SolveGraphically(games.ToFloat(game.Payoff))
 	if err != nil {
-		fmt.Printf("Player 2 LP failed: %v\n", err)
-		return make(Vector, cols)
+		fmt.Println("Failed to solve game:", err)
+		return
 	}
 
-	y := make(Vector, cols)
-	for j := 0; j < cols; j++ {
-		y[j] = ysol[j]
+	fmt.Println("=== Graphical Method ===")
+	labels := make([]string, len(sol.Lines))
+	for k := range labels {
+		if sol.Player == zerosum.RowPlayer {
+			labels[k] = game.ColLabel(k)
+		} else {
+			labels[k] = game.RowLabel(k)
+		}
 	}
-	return y
-}
+	fmt.Printf("Optimal probability of the first strategy: %.4f\n", sol.T)
+	fmt.Print("Active strategies:")
+	for _, k := range sol.Active {
+		fmt.Printf(" %s", labels[k])
+	}
+	fmt.Println()
+	PrintResult(sol.Solution, game)
 
-// sum calculates the sum of vector elements
-func sum(v Vector) float64 {
-	s := 0.0
-	for _, val := range v {
-		s += val
+	if err := sol.RenderPNG(filename, labels, 800, 400); err != nil {
+		fmt.Println("Failed to save graph:", err)
 	}
-	return s
 }
 
-// PrintResult prints the game result
-func PrintResult(result GameResult) {
-	fmt.Println("=== Game Solution ===")
-	fmt.Printf("Player 1 Optimal Strategy: ")
-	for i, val := range result.PlayerXStrategy {
-		fmt.Printf("x%d=%.4f ", i+1, val)
+// exactAndPrint solves the game in rationals and reports any discrepancy
+// with the floating-point solver
+func exactAndPrint(title string, game games.Game) {
+	fmt.Printf("\n### %s ###\n", title)
+	exact, _, issues, err := zerosum.CrossCheck(game.Payoff, 1e-6)
+	if err != nil {
+		fmt.Println("Failed to solve game:", err)
+		return
 	}
-	fmt.Println()
 
-	fmt.Printf("Player 2 Optimal Strategy: ")
-	for i, val := range result.PlayerYStrategy {
-		fmt.Printf("y%d=%.4f ", i+1, val)
+	fmt.Println("=== Exact Solution ===")
+	fmt.Print("Player 1 Optimal Strategy: ")
+	for i, v := range exact.RowStrategy {
+		fmt.Printf("x%d=%s ", i+1, v.RatString())
 	}
 	fmt.Println()
-
-	fmt.Printf("Game Value: %.4f\n", result.GameValue)
+	fmt.Print("Player 2 Optimal Strategy: ")
+	for j, v := range exact.ColStrategy {
+		fmt.Printf("y%d=%s ", j+1, v.RatString())
+	}
 	fmt.Println()
-}
+	fmt.Printf("Game Value: %s\n", exact.Value.RatString())
 
-func ExampleFromLab() matrix.Matrix {
-	return matrix.Matrix{
-		{1, 2, -2},
-		{-1, 0, 1},
-		{1, 1, -1},
+	if len(issues) == 0 {
+		fmt.Println("Float solver agrees with the exact solution.")
+	}
+	for _, issue := range issues {
+		fmt.Println("Discrepancy:", issue)
 	}
 }
 
-func ToMatrix(im [][]int) matrix.Matrix {
-	m := matrix.NewFromShape(len(im), len(im[0]), 0.0)
-	for i := range im {
-		for j := range im[i] {
-			m[i][j] = float64(im[i][j])
-		}
+// resourceAllocation solves a small production plan and reports the shadow
+// price of every resource
+func resourceAllocation() {
+	fmt.Println("\n### PROBLEM 16: Resource allocation with shadow prices ###")
+	// profit 3 and 5 per unit of two products, limited by three plants
+	b := lp.NewBuilder()
+	b.Sensitivity = true
+	doors := b.Var("doors")
+	windows := b.Var("windows")
+	resources := []string{"plant1", "plant2", "plant3"}
+	b.LessEqual("plant1", doors.Expr(), 4)
+	b.LessEqual("plant2", windows.Times(2), 12)
+	b.LessEqual("plant3", lp.Sum(doors.Times(3), windows.Times(2)), 18)
+	b.Maximize(lp.Sum(doors.Times(3), windows.Times(5)))
+	fmt.Print(b)
+
+	sol, err := b.Solve()
+	if err != nil {
+		fmt.Println("Failed to solve LP:", err)
+		return
 	}
-	return m
-}
 
-// SolveLP is a simplified wrapper that expects Aub * x <= bub (inequalities only).
-// c is the objective coefficients for minimization; set maximize=true to maximize (wrapper negates c).
-func SolveLP(c []float64, Aub [][]float64, bub []float64, maximize bool) ([]float64, error) {
-	n := len(c)
-	if len(Aub) != len(bub) {
-		return nil, fmt.Errorf("aub/bub size mismatch")
+	fmt.Printf("Plan: doors=%.4f windows=%.4f  profit = %.4f\n", sol.Values["doors"], sol.Values["windows"], sol.Objective)
+	for _, name := range resources {
+		r := sol.RHSRanges[name]
+		fmt.Printf("%s: slack %.4f, shadow price %.4f, valid for capacity in [%.4f, %.4f]\n",
+			name, sol.Slack[name], sol.Duals[name], r.Lower, r.Upper)
 	}
+	fmt.Printf("Reduced costs: doors %.4f, windows %.4f\n", sol.ReducedCosts["doors"], sol.ReducedCosts["windows"])
+}
 
-	// lpsimplex minimizes, so negate objective for maximization
-	cc := make([]float64, n)
-	copy(cc, c)
-	if maximize {
-		for j := range n {
-			cc[j] = -cc[j]
+// sellerStock picks an integer stock for the seller problem that maximises
+// the expected profit under the demand probabilities. Profit for demand d is
+// a*min(s,d) - b*max(0,s-d) = min(a*s, (a+b)*d - b*s), so one variable per
+// demand bounded by both lines models it exactly
+func sellerStock(k, a, c, alpha, beta int, probs []float64) {
+	fmt.Println("\n### PROBLEM 17: Seller stock as an integer program ###")
+	b := lp.NewBuilder()
+	stock := b.IntVar("stock", 0, float64(k))
+	expected := lp.Expr{}
+	for d := alpha; d <= beta; d++ {
+		profit := b.FreeVar(fmt.Sprintf("profit%d", d))
+		b.LessEqual(fmt.Sprintf("sold%d", d), profit.Expr().Minus(stock.Times(float64(a))), 0)
+		b.LessEqual(fmt.Sprintf("left%d", d), lp.Sum(profit.Expr(), stock.Times(float64(c))), float64((a+c)*d))
+		expected = expected.AddTerm(probs[d-alpha], profit)
+	}
+	b.Maximize(expected)
+
+	for _, sel := range []lp.NodeSelection{lp.BestBound, lp.DepthFirst} {
+		sol, err := b.SolveMILP(lp.MILPOptions{
+			Selection: sel,
+			OnIncumbent: func(inc lp.Incumbent) {
+				fmt.Printf("  node %d: incumbent %.4f, bound %.4f\n", inc.Node, inc.Objective, inc.Bound)
+			},
+		})
+		if err != nil {
+			fmt.Println("Failed to solve MILP:", err)
+			return
 		}
+		fmt.Printf("%s: stock %.0f, expected profit %.4f, %d nodes\n", sel, sol.Values["stock"], sol.Objective, sol.Result.Nodes)
 	}
 
-	maxIter := 1000
-	optRes := lpsimplex.LPSimplex(cc, Aub, bub, nil, nil, nil, nil, false, maxIter, 1e-9, false)
-
-	if !optRes.Success {
-		return nil, fmt.Errorf("lpsimplex failed to solve LP: %s (status=%d)", optRes.Message, optRes.Status)
+	payoff := games.ToFloat(games.SellerProblem(k, a, c, alpha, beta).Payoff)
+	if d, err := uncertainty.BayesCriterion(payoff, probs); err == nil {
+		fmt.Printf("Bayes criterion on the payoff matrix: stock %d, expected profit %.4f\n", d.Best[0], d.Value)
 	}
+}
 
-	if len(optRes.X) == 0 {
-		return nil, fmt.Errorf("lpsimplex returned empty solution")
+// pureAllocation chooses one row of the game with binary variables so that
+// the worst payoff over all columns is as large as possible
+func pureAllocation(name string, g games.Game) {
+	fmt.Printf("\n### %s ###\n", name)
+	b := lp.NewBuilder()
+	choose := make([]lp.Var, len(g.Payoff))
+	pick := lp.Expr{}
+	for i := range g.Payoff {
+		choose[i] = b.BinaryVar(fmt.Sprintf("x%d", i))
+		pick = pick.AddTerm(1, choose[i])
 	}
+	guarantee := b.FreeVar("v")
+	b.Equal("one", pick, 1)
+	for j := range g.Payoff[0] {
+		payoff := guarantee.Expr()
+		for i, row := range g.Payoff {
+			payoff = payoff.AddTerm(-float64(row[j]), choose[i])
+		}
+		b.LessEqual(fmt.Sprintf("col%d", j), payoff, 0)
+	}
+	b.Maximize(guarantee.Expr())
 
-	x := optRes.X
-	if len(x) < n {
-		x2 := make([]float64, n)
-		copy(x2, x)
-		x = x2
-	} else if len(x) > n {
-		x = x[:n]
+	sol, err := b.SolveMILP(lp.MILPOptions{NodeLimit: 1000})
+	if err != nil {
+		fmt.Println("Failed to solve MILP:", err)
+		return
+	}
+	for i, v := range choose {
+		if sol.Values[v.Name()] > 0.5 {
+			fmt.Printf("Allocation %s guarantees %.0f (%d nodes)\n", g.RowLabel(i), sol.Objective, sol.Result.Nodes)
+		}
+	}
+	if d, err := uncertainty.WaldCriterion(games.ToFloat(g.Payoff)); err == nil {
+		fmt.Printf("Maximin over the rows: %.0f\n", d.Value)
 	}
-	return x, nil
 }
 
 func main() {
-	fmt.Println("\n### EXAMPLE FROM LAB ###")
-	exampleGame := ExampleFromLab()
-	result := SolveMatrixGame(exampleGame)
-	PrintResult(result)
-
-	fmt.Println("\n### PROBLEM 1: Modified Coin Game ###")
-	game1 := ToMatrix(games.CoinGame())
-	result1 := SolveMatrixGame(game1)
-	PrintResult(result1)
-
-	fmt.Println("\n### PROBLEM 2: Partners Choosing Values ###")
-	game2 := ToMatrix(games.Game2_s_vals())
-	result2 := SolveMatrixGame(game2)
-	PrintResult(result2)
-
-	fmt.Println("\n### PROBLEM 3: Rock, Paper, Scissors ###")
-	game3 := ToMatrix(games.RPS())
-	result3 := SolveMatrixGame(game3)
-	PrintResult(result3)
-
-	fmt.Println("\n### PROBLEM 4: Two-Finger Morra ###")
-	game4 := ToMatrix(games.Morra(2))
-	result4 := SolveMatrixGame(game4)
-	PrintResult(result4)
+	solveAndPrint("EXAMPLE FROM LAB", ExampleFromLab())
+
+	game1 := games.CoinGame()
+	solveAndPrint("PROBLEM 1: Modified Coin Game", ToMatrix(game1), game1)
+
+	game2 := games.Game2_s_vals()
+	solveAndPrint("PROBLEM 2: Partners Choosing Values", ToMatrix(game2), game2)
+
+	game3 := games.RPS()
+	solveAndPrint("PROBLEM 3: Rock, Paper, Scissors", ToMatrix(game3), game3)
+
+	game4 := games.Morra(2)
+	solveAndPrint("PROBLEM 4: Two-Finger Morra", ToMatrix(game4), game4)
+
+	// the third row is beaten only by mixing the first two
+	reduceAndPrint("PROBLEM 5: Mixed dominance (reduced)", games.Game{Payoff: [][]int{{3, 0}, {0, 3}, {1, 1}}}, zerosum.DominanceOptions{Mixed: true})
+
+	// weak, pure and mixed eliminations, each opening up another one
+	reduceAndPrint("PROBLEM 6: Elimination chain (reduced)", games.Game{Payoff: [][]int{
+		{3, 0, 2, 4},
+		{0, 3, 2, 5},
+		{1, 1, 1, 6},
+		{3, 0, 1, 4},
+	}}, zerosum.DominanceOptions{Weak: true, Mixed: true})
+
+	approximateAndPrint("PROBLEM 7: Four-Finger Morra (fictitious play)", games.Morra(4), 0.05, "images/morra4_brown_robinson.png")
+
+	approximateAndPrint("PROBLEM 8: Seller problem (fictitious play)", games.SellerProblem(5, 10, 4, 0, 5), 0.05, "images/seller_brown_robinson.png")
+
+	graphicalAndPrint("PROBLEM 9: Coin Game (graphical)", games.CoinGame(), "images/coin_graphical.png")
+
+	graphicalAndPrint("PROBLEM 10: Chicken, row payoffs (graphical)", games.ChickenDefault().RowGame(), "images/chicken_graphical.png")
+
+	graphicalAndPrint("PROBLEM 11: 2x4 game (graphical)", games.Game{Payoff: [][]int{{2, 3, 11, 7}, {7, 5, 2, 9}}}, "images/2x4_graphical.png")
+
+	graphicalAndPrint("PROBLEM 12: 4x2 game (graphical)", games.Game{Payoff: [][]int{{2, 7}, {3, 5}, {11, 2}, {7, 9}}}, "images/4x2_graphical.png")
+
+	exactAndPrint("PROBLEM 13: Example from lab (exact)", games.Game{Payoff: [][]int{{1, 2, -2}, {-1, 0, 1}, {1, 1, -1}}})
+
+	exactAndPrint("PROBLEM 14: Rock, Paper, Scissors (exact)", games.RPS())
+
+	exactAndPrint("PROBLEM 15: Three-Finger Morra (exact)", games.Morra(3))
+
+	resourceAllocation()
+
+	sellerStock(5, 10, 4, 0, 5, []float64{0.05, 0.1, 0.2, 0.3, 0.2, 0.15})
+
+	pureAllocation("PROBLEM 18: Blotto pure allocation with binary variables", games.Blotto(3, 3))
 }
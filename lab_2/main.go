@@ -2,8 +2,11 @@ package main
 
 import (
 	"decision-theory/binrels"
+	"decision-theory/binrels/labeled"
 	"flag"
 	"fmt"
+	"os"
+	"strings"
 )
 
 const (
@@ -13,12 +16,43 @@ const (
 	Spring
 )
 
-func set(matrices ...[][]bool) func(int) [][]bool {
-	return func(flag int) [][]bool {
-		if flag < 0 || flag > len(matrices) {
+func set(relations ...*labeled.Relation[string]) func(int) *labeled.Relation[string] {
+	return func(flag int) *labeled.Relation[string] {
+		if flag < 0 || flag >= len(relations) {
 			return nil
 		}
-		return matrices[flag]
+		return relations[flag]
+	}
+}
+
+func load(path string) (*labeled.Relation[string], error) {
+	labels, matrix, err := binrels.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return labeled.FromMatrix(labels, matrix)
+}
+
+func writeHasse(path string, r *labeled.Relation[string]) error {
+	if strings.HasSuffix(path, ".png") {
+		return binrels.RenderHassePNG(path, r.Matrix(), binrels.RenderOptions{Labels: r.Labels()})
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return binrels.WriteHasseDOT(f, r.Matrix(), binrels.DOTOptions{Labels: r.Labels()})
+}
+
+func printElements(elems []string) {
+	if len(elems) == 0 {
+		fmt.Println("∅")
+	}
+	for _, v := range elems {
+		fmt.Println(v)
 	}
 }
 
@@ -37,135 +71,184 @@ func main() {
 	bottom_int := flag.Int("bi", -1, "bottom intersection of matrix")
 	def_dom := flag.Int("dd", -1, "definition domain of matrix")
 	mean_dom := flag.Int("md", -1, "meaning domain of matrix")
+	props := flag.Int("pr", -1, "properties and class of matrix")
 
 	runAll := flag.Bool("all", false, "run all operations")
 
+	first_file := flag.String("f1", "", "file with the first relation (.csv, .json, .rel or 0/1 matrix)")
+	hasse := flag.String("hasse", "", "file (.dot or .png) for the Hasse diagram of the second relation")
+	second_file := flag.String("f2", "", "file with the second relation (.csv, .json, .rel or 0/1 matrix)")
+
 	flag.Parse()
 
 	rels := []string{"О", "З", "Л", "В"}
 
-	is_same := binrels.Zero(len(rels))
-	not_colder := binrels.Zero(len(rels))
-
-	is_same[Fall][Fall] = true
-	is_same[Winter][Winter] = true
-	is_same[Summer][Summer] = true
-	is_same[Spring][Spring] = true
-
-	not_colder[Fall][Winter] = true
-
-	not_colder[Spring][Fall] = true
-	not_colder[Spring][Winter] = true
-
-	not_colder[Summer][Fall] = true
-	not_colder[Summer][Spring] = true
-	not_colder[Summer][Winter] = true
-
-	not_colder = binrels.Union(not_colder, is_same)
+	is_same, _ := labeled.FromPairs(rels,
+		[2]string{rels[Fall], rels[Fall]},
+		[2]string{rels[Winter], rels[Winter]},
+		[2]string{rels[Summer], rels[Summer]},
+		[2]string{rels[Spring], rels[Spring]},
+	)
+
+	not_colder, _ := labeled.FromPairs(rels,
+		[2]string{rels[Fall], rels[Winter]},
+		[2]string{rels[Spring], rels[Fall]},
+		[2]string{rels[Spring], rels[Winter]},
+		[2]string{rels[Summer], rels[Fall]},
+		[2]string{rels[Summer], rels[Spring]},
+		[2]string{rels[Summer], rels[Winter]},
+	)
+
+	not_colder, _ = not_colder.Union(is_same)
+
+	if *first_file != "" {
+		loaded, err := load(*first_file)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		is_same = loaded
+		rels = is_same.Carrier()
+	}
+	if *second_file != "" {
+		loaded, err := load(*second_file)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		not_colder = loaded
+	}
+	if aligned, err := is_same.Align(not_colder); err != nil {
+		fmt.Println("relations are over different sets:", err)
+		return
+	} else {
+		not_colder = aligned
+	}
 
-	getMatrix := set(is_same, not_colder)
+	getRelation := set(is_same, not_colder)
 
 	if *runAll || *to_print {
 		fmt.Println("Is Same:")
-		binrels.PrintWithSource(rels, is_same)
+		is_same.Print()
 		fmt.Println("\nNot Colder:")
-		binrels.PrintWithSource(rels, not_colder)
+		not_colder.Print()
 	}
 
 	if *runAll || *intersect {
-		res := binrels.Intersection(is_same, not_colder)
+		res, _ := is_same.Intersection(not_colder)
 		fmt.Println("\nIntersection:")
-		binrels.PrintWithSource(rels, res)
+		res.Print()
 	}
 	if *runAll || *union {
-		res := binrels.Union(is_same, not_colder)
+		res, _ := is_same.Union(not_colder)
 		fmt.Println("\nUnion:")
-		binrels.PrintWithSource(rels, res)
+		res.Print()
 	}
 	if *runAll || *diff {
-		res := binrels.Diff(is_same, not_colder)
+		res, _ := is_same.Diff(not_colder)
 		fmt.Println("\nDifference (is_same - not_colder):")
-		binrels.PrintWithSource(rels, res)
+		res.Print()
 	}
 	if *runAll || *symmDiff {
-		res := binrels.SymmDiff(is_same, not_colder)
+		res, _ := is_same.SymmDiff(not_colder)
 		fmt.Println("\nSymmetric Difference:")
-		binrels.PrintWithSource(rels, res)
+		res.Print()
 	}
 	if *runAll || *composition {
-		res := binrels.Composition(is_same, not_colder)
+		res, _ := is_same.Composition(not_colder)
 		fmt.Println("\nComposition (is_same o not_colder):")
-		binrels.PrintWithSource(rels, res)
+		res.Print()
 	}
 	if *runAll || *reverse != 0 {
-		var trgt = getMatrix(*reverse)
+		var trgt = getRelation(*reverse)
 
 		if trgt != nil {
-			res := binrels.Transpose(trgt)
 			fmt.Println("\nTranspose:")
-			binrels.PrintWithSource(rels, res)
+			trgt.Transpose().Print()
 		}
 	}
 	if *runAll || *complement != 0 {
-		var trgt = getMatrix(*complement)
+		var trgt = getRelation(*complement)
 
 		if trgt != nil {
-			res := binrels.Complement(trgt)
 			fmt.Println("\nComplement:")
-			binrels.PrintWithSource(rels, res)
+			trgt.Complement().Print()
 		}
 	}
 	if *runAll || *top_int >= 0 && *top_int < len(rels) {
-		same_top_int := binrels.TopIntersection(is_same, *top_int)
-		not_colder_top_int := binrels.TopIntersection(not_colder, *top_int)
+		same_top_int, _ := is_same.Upper(rels[*top_int])
+		not_colder_top_int, _ := not_colder.Upper(rels[*top_int])
 
 		fmt.Printf("\nTop Intersection of is_same with %s:\n", rels[*top_int])
-		for _, v := range same_top_int {
-			fmt.Println(rels[v])
-		}
+		printElements(same_top_int)
 		fmt.Printf("\nTop Intersection of not_colder with %s:\n", rels[*top_int])
-		for _, v := range not_colder_top_int {
-			fmt.Println(rels[v])
-		}
+		printElements(not_colder_top_int)
 	}
 	if *runAll && *bottom_int >= 0 && *bottom_int < len(rels) {
-		same_bottom_int := binrels.BottomIntersection(is_same, *bottom_int)
-		not_colder_bottom_int := binrels.BottomIntersection(not_colder, *bottom_int)
+		same_bottom_int, _ := is_same.Lower(rels[*bottom_int])
+		not_colder_bottom_int, _ := not_colder.Lower(rels[*bottom_int])
 
 		fmt.Printf("\nBottom Intersection of is_same with %s:\n", rels[*bottom_int])
-		for _, v := range same_bottom_int {
-			fmt.Println(rels[v])
-		}
+		printElements(same_bottom_int)
 		fmt.Printf("\nBottom Intersection of not_colder with %s:\n", rels[*bottom_int])
-		for _, v := range not_colder_bottom_int {
-			fmt.Println(rels[v])
-		}
+		printElements(not_colder_bottom_int)
 	}
 	if *runAll && *def_dom > -1 && *def_dom < 2 {
-		var trgt = getMatrix(*def_dom)
+		var trgt = getRelation(*def_dom)
 		if trgt != nil {
-			res := binrels.DefinitionDomain(trgt)
 			fmt.Println("\nDefinition Domain:")
-			if len(res) == 0 {
-				fmt.Println("∅")
-			}
-			for _, v := range res {
-				fmt.Println(rels[v])
-			}
+			printElements(trgt.DefinitionDomain())
 		}
 	}
 	if *runAll && *mean_dom >= 0 && *mean_dom < 2 {
-		var trgt = getMatrix(*mean_dom)
+		var trgt = getRelation(*mean_dom)
 
 		if trgt != nil {
-			res := binrels.MeaningDomain(trgt)
 			fmt.Println("\nMeaning Domain:")
-			if len(res) == 0 {
-				fmt.Println("∅")
+			printElements(trgt.MeaningDomain())
+		}
+	}
+	if *runAll || *props >= 0 && *props < 2 {
+		for idx, name := range []string{"is_same", "not_colder"} {
+			if !*runAll && idx != *props {
+				continue
 			}
-			for _, v := range res {
-				fmt.Println(rels[v])
+
+			report, err := binrels.Analyze(getRelation(idx).Matrix())
+			if err != nil {
+				fmt.Println(err)
+				continue
 			}
+			fmt.Printf("\nProperties of %s:\n", name)
+			fmt.Print(report)
+			for _, p := range binrels.AllProperties() {
+				for _, v := range report.Violations[p] {
+					names := make([]string, len(v))
+					for k := range v {
+						names[k] = rels[v[k]]
+					}
+					fmt.Printf("  not %s: %v\n", p, names)
+				}
+			}
+			fmt.Println("Classes:", report.Classes())
+
+			matrix := getRelation(idx).Matrix()
+			fmt.Println("Preference structure:", binrels.Decompose(matrix).Type())
+			if extensions, _, err := binrels.LinearExtensions(matrix, 10); err == nil {
+				fmt.Println("Rankings consistent with it:")
+				for _, ext := range extensions {
+					names := make([]string, len(ext))
+					for k := range ext {
+						names[k] = rels[ext[k]]
+					}
+					fmt.Println(" ", names)
+				}
+			}
+		}
+	}
+	if *hasse != "" {
+		if err := writeHasse(*hasse, not_colder); err != nil {
+			fmt.Println(err)
 		}
 	}
 }
@@ -2,10 +2,51 @@ package main
 
 import (
 	"decision-theory/binrels"
+	"decision-theory/graph"
+	"flag"
 	"fmt"
+	"os"
 )
 
+func names(labels []string, idx []int) []string {
+	res := make([]string, len(idx))
+	for i, v := range idx {
+		res[i] = labels[v]
+	}
+	return res
+}
+
+func edges(labels []string, pairs [][2]int) []string {
+	res := make([]string, len(pairs))
+	for i, p := range pairs {
+		res[i] = labels[p[0]] + "->" + labels[p[1]]
+	}
+	return res
+}
+
+func writeDOT(path string, labels []string, rels [][]bool) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return binrels.WriteDOT(f, rels, binrels.DOTOptions{
+		Name:                "lab3",
+		Labels:              labels,
+		HighlightComponents: true,
+	})
+}
+
 func main() {
+	file := flag.String("f", "", "file with the relation (.csv, .json, .rel or 0/1 matrix)")
+	out := flag.String("o", "", "file to save the mutual reachability relation to")
+	dot := flag.String("dot", "", "file to export the relation to as a Graphviz digraph")
+	png := flag.String("png", "", "file to draw the relation to")
+	circular := flag.Bool("circle", false, "use circular layout when drawing")
+
+	flag.Parse()
+
 	rels := binrels.Zero(4)
 
 	//0011
@@ -23,11 +64,101 @@ func main() {
 	rels[3][0] = true
 	rels[3][1] = true
 
+	labels := binrels.DefaultLabels(len(rels))
+	if *file != "" {
+		var err error
+		labels, rels, err = binrels.ReadFile(*file)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+
 	fmt.Println("\nInitial Relation:")
-	binrels.Print(rels)
+	binrels.PrintWithSource(labels, rels)
+
+	report, err := binrels.Analyze(rels)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println("\nProperties:")
+	fmt.Print(report)
+	fmt.Println("Classes:", report.Classes())
 
 	mrRels := binrels.MutualReachability(rels)
 
 	fmt.Println("\nMutual Reachability Relation:")
 	binrels.Print(mrRels)
+
+	if *out != "" {
+		if err := binrels.WriteFile(*out, labels, mrRels); err != nil {
+			fmt.Println(err)
+		}
+	}
+
+	if *dot != "" {
+		if err := writeDOT(*dot, labels, rels); err != nil {
+			fmt.Println(err)
+		}
+	}
+	if *png != "" {
+		layout := graph.LayeredLayout
+		if *circular {
+			layout = graph.CircularLayout
+		}
+		err := binrels.RenderPNG(*png, rels, binrels.RenderOptions{
+			Labels:              labels,
+			Layout:              layout,
+			HighlightComponents: true,
+		})
+		if err != nil {
+			fmt.Println(err)
+		}
+	}
+
+	closures := []struct {
+		name   string
+		change binrels.Change
+	}{
+		{"Reflexive Closure", binrels.ReflexiveClosure(rels)},
+		{"Symmetric Closure", binrels.SymmetricClosure(rels)},
+		{"Transitive Closure", binrels.TransitiveClosureChange(rels)},
+		{"Equivalence Closure", binrels.EquivalenceClosure(rels)},
+		{"Transitive Reduction", binrels.TransitiveReduction(rels)},
+		{"Reflexive-Transitive Kernel", binrels.ReflexiveTransitiveKernel(rels)},
+	}
+	for _, c := range closures {
+		fmt.Printf("\n%s:\n", c.name)
+		binrels.PrintWithSource(labels, c.change.Result)
+		fmt.Println("added:", edges(labels, c.change.Added))
+		fmt.Println("removed:", edges(labels, c.change.Removed))
+	}
+
+	condensation := binrels.Condense(rels)
+	fmt.Println("\nStrongly Connected Components:")
+	for c, comp := range condensation.Components {
+		fmt.Printf("C%d: %v\n", c, names(labels, comp))
+	}
+
+	fmt.Println("\nCondensation:")
+	binrels.Print(condensation.Relation)
+	fmt.Println("Topological order of components:", condensation.Order())
+
+	if _, err := binrels.TopologicalSort(rels); err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println("\nMaximal:", names(labels, binrels.Maximal(rels)))
+	fmt.Println("Minimal:", names(labels, binrels.Minimal(rels)))
+	if core, err := binrels.Core(rels); err != nil {
+		fmt.Println("Core:", err)
+	} else {
+		fmt.Println("Core:", names(labels, core))
+	}
+	for k := 1; k <= 4; k++ {
+		kmax, _ := binrels.KMax(rels, k)
+		kopt, _ := binrels.KOpt(rels, k)
+		fmt.Printf("%d-max: %v, %d-opt: %v\n", k, names(labels, kmax), k, names(labels, kopt))
+	}
 }
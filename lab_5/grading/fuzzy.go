@@ -0,0 +1,54 @@
+package grading
+
+import (
+	"decision-theory/binrels"
+	"decision-theory/fuzzyrels"
+	"fmt"
+)
+
+// PreferenceRelation turns expert counts into a fuzzy preference relation:
+// μ(i,j) is the share of experts who prefer i over j, μ(i,i) = 1.
+func PreferenceRelation(matrix [][]int, experts int) [][]float64 {
+	pref := fuzzyrels.Zero(len(matrix))
+	for i := range matrix {
+		for j := range matrix[i] {
+			if i == j {
+				pref[i][j] = 1
+			} else {
+				pref[i][j] = float64(matrix[i][j]) / float64(experts)
+			}
+		}
+	}
+	return pref
+}
+
+func labels(alts []int, idx []int) []int {
+	res := make([]int, len(idx))
+	for k, i := range idx {
+		res[k] = alts[i]
+	}
+	return res
+}
+
+// FuzzyChoice reports Orlovsky's non-dominated alternatives of the fuzzy
+// preference and the crisp majority relation obtained by its 0.5-cut.
+func FuzzyChoice(alts []int, pref [][]float64) {
+	fmt.Println("\nFuzzy preference relation:")
+	fuzzyrels.Print(pref)
+
+	nd := fuzzyrels.NonDominance(pref)
+	fmt.Println("\nOrlovsky non-dominance:")
+	for i, v := range nd {
+		fmt.Printf("Alternative %d: %.2f\n", alts[i], v)
+	}
+	best, degree := fuzzyrels.Choose(pref)
+	fmt.Printf("Chosen: %v (degree %.2f)\n", labels(alts, best), degree)
+
+	cut := fuzzyrels.AlphaCut(pref, 0.5).Matrix()
+	fmt.Println("\n0.5-cut (majority relation):")
+	binrels.Print(cut)
+	if report, err := binrels.Analyze(cut); err == nil {
+		fmt.Print(report)
+	}
+	fmt.Println("Maximal:", labels(alts, binrels.Maximal(cut)))
+}
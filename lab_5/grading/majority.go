@@ -0,0 +1,53 @@
+package grading
+
+import (
+	"decision-theory/binrels"
+	"fmt"
+)
+
+// Majority asks every expert for a full ranking of the alternatives and
+// reports the majority relation of the profile with its Condorcet winner
+// and loser, majority cycles and the Smith and Schwartz sets.
+func Majority(n, experts int) {
+	alts := alternatives(n)
+
+	profile := make([][]int, 0, experts)
+	fmt.Printf("Each expert ranks all %d alternatives, best first.\n", n)
+	for e := 0; e < experts; e++ {
+		fmt.Printf("Ranking of expert %d: ", e+1)
+		ranking := make([]int, n)
+		for k := range ranking {
+			fmt.Scan(&ranking[k])
+			ranking[k]--
+		}
+		if _, err := binrels.PairwiseCounts(n, [][]int{ranking}); err != nil {
+			fmt.Println("Invalid ranking, try again.")
+			e--
+			continue
+		}
+		profile = append(profile, ranking)
+	}
+
+	res, err := binrels.CondorcetAnalysis(n, profile)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println("\nMajority relation:")
+	binrels.Print(res.Majority)
+
+	name := func(x int) string {
+		if x < 0 {
+			return "none"
+		}
+		return fmt.Sprint(alts[x])
+	}
+	fmt.Println("Condorcet winner:", name(res.Winner))
+	fmt.Println("Condorcet loser:", name(res.Loser))
+	for _, c := range res.Cycles {
+		fmt.Println("Majority cycle:", labels(alts, c))
+	}
+	fmt.Println("Smith set:", labels(alts, res.Smith))
+	fmt.Println("Schwartz set:", labels(alts, res.Schwartz))
+}
@@ -129,4 +129,6 @@ func Thurstone(n, experts int) {
 		fmt.Printf("%d) Alternative %d -> %.4f (%.2f%%)\n", rank+1, r.alt, r.score, probs[r.alt-1]*100)
 	}
 
+	FuzzyChoice(alts, PreferenceRelation(matrix, experts))
+
 }
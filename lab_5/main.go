@@ -11,8 +11,9 @@ func main() {
 	midpoint := flag.Bool("m", false, "Use Midpoint grading method")
 	churchmanAckoff := flag.Bool("c", false, "Use Churchman-Ackoff grading method")
 	thurstone := flag.Bool("t", false, "Use Thurstone grading method")
+	majority := flag.Bool("r", false, "Use majority voting over expert rankings")
 	n := flag.Int("n", 0, "Number of alternatives")
-	experts := flag.Int("e", 0, "Number of experts (for Thurstone method)")
+	experts := flag.Int("e", 0, "Number of experts (for Thurstone and majority methods)")
 
 	flag.Parse()
 
@@ -25,14 +26,16 @@ Options:
 	-m              Use Midpoint grading method
 	-c              Use Churchman-Ackoff grading method
 	-t              Use Thurstone grading method
+	-r              Use majority voting over expert rankings
 	-n <number>     Number of alternatives (required)
-	-e <number>     Number of experts (required for Thurstone method)
+	-e <number>     Number of experts (required for Thurstone and majority)
 
 Example:
 	grade -d -n 5
 	grade -m -n 4
 	grade -c -n 6
 	grade -t -n 5 -e 10
+	grade -r -n 4 -e 5
 `
 
 	if flag.NFlag() == 0 {
@@ -55,6 +58,8 @@ Example:
 		grading.ChurchmanAckoff(*n)
 	} else if *thurstone && *experts > 0 {
 		grading.Thurstone(*n, *experts)
+	} else if *majority && *experts > 0 {
+		grading.Majority(*n, *experts)
 	} else {
 		fmt.Println(usage)
 	}
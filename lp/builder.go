@@ -0,0 +1,462 @@
+package lp
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Var is a variable declared in a Builder.
+type Var struct {
+	index int
+	name  string
+}
+
+func (v Var) Name() string {
+	return v.name
+}
+
+// Expr is a linear expression Σ coeff·var + Const. Terms keep the order in
+// which variables first appear; repeated variables are merged.
+type Expr struct {
+	vars   []Var
+	coeffs []float64
+	Const  float64
+}
+
+// Term returns the expression k·v.
+func Term(k float64, v Var) Expr {
+	return Expr{vars: []Var{v}, coeffs: []float64{k}}
+}
+
+func Const(k float64) Expr {
+	return Expr{Const: k}
+}
+
+// Sum adds expressions.
+func Sum(exprs ...Expr) Expr {
+	res := Expr{}
+	for _, e := range exprs {
+		res = res.Plus(e)
+	}
+	return res
+}
+
+func (v Var) Expr() Expr {
+	return Term(1, v)
+}
+
+func (v Var) Times(k float64) Expr {
+	return Term(k, v)
+}
+
+func (e Expr) Plus(o Expr) Expr {
+	res := Expr{vars: append([]Var{}, e.vars...), coeffs: append([]float64{}, e.coeffs...), Const: e.Const + o.Const}
+	for t, v := range o.vars {
+		merged := false
+		for k, w := range res.vars {
+			if w.index == v.index {
+				res.coeffs[k] += o.coeffs[t]
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			res.vars = append(res.vars, v)
+			res.coeffs = append(res.coeffs, o.coeffs[t])
+		}
+	}
+	return res
+}
+
+func (e Expr) Minus(o Expr) Expr {
+	return e.Plus(o.Times(-1))
+}
+
+func (e Expr) Times(k float64) Expr {
+	res := Expr{vars: append([]Var{}, e.vars...), coeffs: make([]float64, len(e.coeffs)), Const: e.Const * k}
+	for t, c := range e.coeffs {
+		res.coeffs[t] = c * k
+	}
+	return res
+}
+
+// AddTerm returns e + k·v.
+func (e Expr) AddTerm(k float64, v Var) Expr {
+	return e.Plus(Term(k, v))
+}
+
+func (e Expr) String() string {
+	var sb strings.Builder
+	for t, v := range e.vars {
+		c := e.coeffs[t]
+		if c == 0 {
+			continue
+		}
+		switch {
+		case sb.Len() == 0 && c < 0:
+			sb.WriteString("-")
+		case sb.Len() > 0 && c < 0:
+			sb.WriteString(" - ")
+		case sb.Len() > 0:
+			sb.WriteString(" + ")
+		}
+		if a := math.Abs(c); a != 1 {
+			sb.WriteString(formatNumber(a) + " ")
+		}
+		sb.WriteString(v.name)
+	}
+	switch {
+	case sb.Len() == 0:
+		return formatNumber(e.Const)
+	case e.Const > 0:
+		sb.WriteString(" + " + formatNumber(e.Const))
+	case e.Const < 0:
+		sb.WriteString(" - " + formatNumber(-e.Const))
+	}
+	return sb.String()
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+type namedConstraint struct {
+	name  string
+	lhs   Expr
+	sense Sense
+	rhs   float64
+}
+
+// Builder assembles a Model from named variables and constraints written
+// as linear expressions. Declaration errors such as duplicate names are
+// kept and returned by Build and Solve.
+type Builder struct {
+	// Name and ObjectiveName are carried through the LP and MPS formats;
+	// an empty ObjectiveName is written as obj.
+	Name          string
+	ObjectiveName string
+	// Sensitivity asks Solve for duals, reduced costs and RHS ranges.
+	Sensitivity bool
+
+	vars        []Var
+	bounds      []Bound
+	integer     []bool
+	varIndex    map[string]int
+	constraints []namedConstraint
+	conIndex    map[string]int
+	objective   Expr
+	maximize    bool
+	err         error
+}
+
+func NewBuilder() *Builder {
+	return &Builder{varIndex: map[string]int{}, conIndex: map[string]int{}}
+}
+
+func (b *Builder) fail(format string, args ...any) {
+	if b.err == nil {
+		b.err = fmt.Errorf("lp: "+format, args...)
+	}
+}
+
+// Var declares a non-negative variable.
+func (b *Builder) Var(name string) Var {
+	return b.BoundedVar(name, 0, math.Inf(1))
+}
+
+// FreeVar declares a variable without bounds.
+func (b *Builder) FreeVar(name string) Var {
+	return b.BoundedVar(name, math.Inf(-1), math.Inf(1))
+}
+
+func (b *Builder) BoundedVar(name string, lower, upper float64) Var {
+	if _, ok := b.varIndex[name]; ok {
+		b.fail("variable %q declared twice", name)
+	}
+	if name == "" {
+		b.fail("variable %d has no name", len(b.vars))
+	}
+	if lower > upper {
+		b.fail("variable %q has lower bound %v above upper bound %v", name, lower, upper)
+	}
+	v := Var{index: len(b.vars), name: name}
+	b.varIndex[name] = v.index
+	b.vars = append(b.vars, v)
+	b.bounds = append(b.bounds, Bound{Lower: lower, Upper: upper})
+	b.integer = append(b.integer, false)
+	return v
+}
+
+// IntVar declares an integer variable in [lower, upper].
+func (b *Builder) IntVar(name string, lower, upper float64) Var {
+	v := b.BoundedVar(name, lower, upper)
+	b.integer[v.index] = true
+	return v
+}
+
+// BinaryVar declares a 0/1 variable, e.g. whether an alternative is chosen.
+func (b *Builder) BinaryVar(name string) Var {
+	return b.IntVar(name, 0, 1)
+}
+
+// HasIntegers reports whether any variable is integer.
+func (b *Builder) HasIntegers() bool {
+	for _, v := range b.integer {
+		if v {
+			return true
+		}
+	}
+	return false
+}
+
+// Lookup returns the variable declared under name.
+func (b *Builder) Lookup(name string) (Var, bool) {
+	i, ok := b.varIndex[name]
+	if !ok {
+		return Var{}, false
+	}
+	return b.vars[i], true
+}
+
+// Vars returns the variables in declaration order.
+func (b *Builder) Vars() []Var {
+	return append([]Var{}, b.vars...)
+}
+
+// ConstraintNames returns the constraint names in declaration order.
+func (b *Builder) ConstraintNames() []string {
+	res := make([]string, len(b.constraints))
+	for i, c := range b.constraints {
+		res[i] = c.name
+	}
+	return res
+}
+
+// Constrain adds lhs (sense) rhs; an empty name is replaced by c1, c2, ...
+// The constant of lhs is moved to the right-hand side.
+func (b *Builder) Constrain(name string, lhs Expr, sense Sense, rhs float64) {
+	if name == "" {
+		name = "c" + strconv.Itoa(len(b.constraints)+1)
+	}
+	if _, ok := b.conIndex[name]; ok {
+		b.fail("constraint %q declared twice", name)
+	}
+	b.checkVars(lhs)
+	b.conIndex[name] = len(b.constraints)
+	b.constraints = append(b.constraints, namedConstraint{name: name, lhs: lhs, sense: sense, rhs: rhs})
+}
+
+func (b *Builder) LessEqual(name string, lhs Expr, rhs float64) {
+	b.Constrain(name, lhs, LessEqual, rhs)
+}
+
+func (b *Builder) GreaterEqual(name string, lhs Expr, rhs float64) {
+	b.Constrain(name, lhs, GreaterEqual, rhs)
+}
+
+func (b *Builder) Equal(name string, lhs Expr, rhs float64) {
+	b.Constrain(name, lhs, Equal, rhs)
+}
+
+func (b *Builder) Minimize(e Expr) {
+	b.checkVars(e)
+	b.objective, b.maximize = e, false
+}
+
+func (b *Builder) Maximize(e Expr) {
+	b.checkVars(e)
+	b.objective, b.maximize = e, true
+}
+
+// checkVars rejects variables that belong to another builder.
+func (b *Builder) checkVars(e Expr) {
+	for _, v := range e.vars {
+		if v.index >= len(b.vars) || b.vars[v.index].name != v.name {
+			b.fail("variable %q is not declared in this model", v.name)
+		}
+	}
+}
+
+func (b *Builder) coefficients(e Expr) []float64 {
+	row := make([]float64, len(b.vars))
+	for t, v := range e.vars {
+		row[v.index] += e.coeffs[t]
+	}
+	return row
+}
+
+// Build returns the Model with variables and constraints in declaration
+// order.
+func (b *Builder) Build() (*Model, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	// the readers set bounds one side at a time, so check them here
+	for j, bd := range b.bounds {
+		if bd.Lower > bd.Upper {
+			return nil, fmt.Errorf("lp: variable %q has lower bound %v above upper bound %v", b.vars[j].name, bd.Lower, bd.Upper)
+		}
+	}
+	m := NewModel(b.coefficients(b.objective), b.maximize)
+	m.Sensitivity = b.Sensitivity
+	m.Bounds = append([]Bound{}, b.bounds...)
+	if b.HasIntegers() {
+		m.Integer = append([]bool{}, b.integer...)
+	}
+	for _, c := range b.constraints {
+		m.AddConstraint(b.coefficients(c.lhs), c.sense, c.rhs-c.lhs.Const)
+	}
+	return m, nil
+}
+
+// Solution is a Result addressed by variable and constraint names. The
+// sensitivity maps are nil unless Builder.Sensitivity is set.
+type Solution struct {
+	Result Result
+	// Objective includes the constant of the objective expression.
+	Objective    float64
+	Values       map[string]float64
+	ReducedCosts map[string]float64
+	Slack        map[string]float64
+	Duals        map[string]float64
+	RHSRanges    map[string]Range
+}
+
+// Solve solves the LP relaxation; integer variables are treated as
+// continuous. Use SolveMILP to keep them integral.
+func (b *Builder) Solve() (Solution, error) {
+	m, err := b.Build()
+	if err != nil {
+		return Solution{}, err
+	}
+	res, err := m.Solve()
+	if err != nil {
+		return Solution{}, err
+	}
+
+	sol := Solution{
+		Result:    res,
+		Objective: res.Objective + b.objective.Const,
+		Values:    map[string]float64{},
+		Slack:     map[string]float64{},
+	}
+	for j, v := range b.vars {
+		sol.Values[v.name] = res.X[j]
+	}
+	for i, c := range b.constraints {
+		sol.Slack[c.name] = res.Slack[i]
+	}
+	if !m.Sensitivity {
+		return sol, nil
+	}
+
+	sol.ReducedCosts = map[string]float64{}
+	sol.Duals = map[string]float64{}
+	sol.RHSRanges = map[string]Range{}
+	for j, v := range b.vars {
+		sol.ReducedCosts[v.name] = res.ReducedCosts[j]
+	}
+	for i, c := range b.constraints {
+		shift := c.lhs.Const
+		sol.Duals[c.name] = res.Duals[i]
+		sol.RHSRanges[c.name] = Range{Lower: res.RHSRanges[i].Lower + shift, Upper: res.RHSRanges[i].Upper + shift}
+	}
+	return sol, nil
+}
+
+// MILPSolution is a MILPResult addressed by variable names.
+type MILPSolution struct {
+	Result MILPResult
+	// Objective and Bound include the constant of the objective expression.
+	Objective float64
+	Bound     float64
+	Values    map[string]float64
+}
+
+func (b *Builder) SolveMILP(opts MILPOptions) (MILPSolution, error) {
+	m, err := b.Build()
+	if err != nil {
+		return MILPSolution{}, err
+	}
+	res, err := m.SolveMILP(opts)
+	if err != nil {
+		return MILPSolution{}, err
+	}
+
+	sol := MILPSolution{
+		Result:    res,
+		Objective: res.Objective + b.objective.Const,
+		Bound:     res.Bound + b.objective.Const,
+		Values:    map[string]float64{},
+	}
+	for j, v := range b.vars {
+		sol.Values[v.name] = res.X[j]
+	}
+	return sol, nil
+}
+
+// String prints the model in algebraic form:
+//
+//	maximize 3 x + 5 y
+//	subject to
+//	  plant1: x <= 4
+//	bounds
+//	  x >= 0
+//	integer
+//	  x
+func (b *Builder) String() string {
+	var sb strings.Builder
+	if b.maximize {
+		sb.WriteString("maximize ")
+	} else {
+		sb.WriteString("minimize ")
+	}
+	sb.WriteString(b.objective.String() + "\n")
+
+	if len(b.constraints) > 0 {
+		sb.WriteString("subject to\n")
+		width := 0
+		for _, c := range b.constraints {
+			width = max(width, len(c.name))
+		}
+		for _, c := range b.constraints {
+			fmt.Fprintf(&sb, "  %-*s %s %s %s\n", width+1, c.name+":", c.lhs, c.sense, formatNumber(c.rhs))
+		}
+	}
+
+	if len(b.vars) > 0 {
+		sb.WriteString("bounds\n")
+		for j, v := range b.vars {
+			sb.WriteString("  " + formatBound(v.name, b.bounds[j]) + "\n")
+		}
+	}
+
+	if b.HasIntegers() {
+		sb.WriteString("integer\n ")
+		for j, v := range b.vars {
+			if b.integer[j] {
+				sb.WriteString(" " + v.name)
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func formatBound(name string, bd Bound) string {
+	lower, upper := !math.IsInf(bd.Lower, -1), !math.IsInf(bd.Upper, 1)
+	switch {
+	case lower && upper && bd.Lower == bd.Upper:
+		return name + " = " + formatNumber(bd.Lower)
+	case lower && upper:
+		return formatNumber(bd.Lower) + " <= " + name + " <= " + formatNumber(bd.Upper)
+	case lower:
+		return name + " >= " + formatNumber(bd.Lower)
+	case upper:
+		return name + " <= " + formatNumber(bd.Upper)
+	default:
+		return name + " free"
+	}
+}
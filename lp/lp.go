@@ -0,0 +1,22 @@
+package lp
+
+import "fmt"
+
+// SolveLP is a simplified wrapper that expects Aub * x <= bub (inequalities only).
+// c is the objective coefficients for minimization; set maximize=true to maximize.
+// Use Model for equalities, bounds and sensitivity.
+func SolveLP(c []float64, Aub [][]float64, bub []float64, maximize bool) ([]float64, error) {
+	if len(Aub) != len(bub) {
+		return nil, fmt.Errorf("aub/bub size mismatch")
+	}
+
+	m := NewModel(c, maximize)
+	for i := range Aub {
+		m.AddConstraint(Aub[i], LessEqual, bub[i])
+	}
+	res, err := m.Solve()
+	if err != nil {
+		return nil, err
+	}
+	return res.X, nil
+}
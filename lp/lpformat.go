@@ -0,0 +1,658 @@
+package lp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// CPLEX LP format, the subset understood here:
+//
+//	\ comment
+//	Maximize
+//	 obj: 3 x + 5 y
+//	Subject To
+//	 c1: x + 2 y <= 14
+//	Bounds
+//	 0 <= x <= 4
+//	 y free
+//	Generals
+//	 x
+//	Binaries
+//	 z
+//	End
+//
+// Section keywords stand on their own lines; constraints may span lines. A
+// "\Problem name: ..." comment sets the model name.
+
+type tokenKind int
+
+const (
+	tokName tokenKind = iota
+	tokNumber
+	tokSign
+	tokRelation
+	tokColon
+	tokStar
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	num  float64
+	line int
+}
+
+type section int
+
+const (
+	sectionNone section = iota
+	sectionObjective
+	sectionConstraints
+	sectionBounds
+	sectionGenerals
+	sectionBinaries
+	sectionEnd
+)
+
+var lpSections = map[string]section{
+	"maximize": sectionObjective, "maximise": sectionObjective, "maximum": sectionObjective, "max": sectionObjective,
+	"minimize": sectionObjective, "minimise": sectionObjective, "minimum": sectionObjective, "min": sectionObjective,
+	"subject to": sectionConstraints, "such that": sectionConstraints, "st": sectionConstraints, "s.t.": sectionConstraints, "st.": sectionConstraints,
+	"bounds": sectionBounds, "bound": sectionBounds,
+	"generals": sectionGenerals, "general": sectionGenerals, "gen": sectionGenerals,
+	"binaries": sectionBinaries, "binary": sectionBinaries, "bin": sectionBinaries,
+	"end": sectionEnd,
+}
+
+func isNameStart(r byte) bool {
+	return !isSpace(r) && !strings.ContainsRune("+-<>=:*0123456789.", rune(r))
+}
+
+func isNameChar(r byte) bool {
+	return !isSpace(r) && !strings.ContainsRune("+-<>=:*", rune(r))
+}
+
+func isSpace(r byte) bool {
+	return r == ' ' || r == '\t' || r == '\r' || r == '\n'
+}
+
+func isDigit(r byte) bool {
+	return r >= '0' && r <= '9'
+}
+
+func tokenize(s string, line int) ([]token, error) {
+	res := []token{}
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case isSpace(c):
+			i++
+		case c == '+' || c == '-':
+			res = append(res, token{kind: tokSign, text: string(c), line: line})
+			i++
+		case c == ':':
+			res = append(res, token{kind: tokColon, text: ":", line: line})
+			i++
+		case c == '*':
+			res = append(res, token{kind: tokStar, text: "*", line: line})
+			i++
+		case c == '<' || c == '>' || c == '=':
+			j := i + 1
+			if j < len(s) && (s[j] == '=' || s[j] == '<' || s[j] == '>') {
+				j++
+			}
+			rel := s[i:j]
+			switch rel {
+			case "<", "<=", "=<":
+				rel = "<="
+			case ">", ">=", "=>":
+				rel = ">="
+			case "=":
+			default:
+				return nil, fmt.Errorf("lp: line %d: unknown relation %q", line, rel)
+			}
+			res = append(res, token{kind: tokRelation, text: rel, line: line})
+			i = j
+		case isDigit(c) || c == '.':
+			j := i
+			for j < len(s) && (isDigit(s[j]) || s[j] == '.') {
+				j++
+			}
+			if j < len(s) && (s[j] == 'e' || s[j] == 'E') {
+				k := j + 1
+				if k < len(s) && (s[k] == '+' || s[k] == '-') {
+					k++
+				}
+				if k < len(s) && isDigit(s[k]) {
+					for k < len(s) && isDigit(s[k]) {
+						k++
+					}
+					j = k
+				}
+			}
+			v, err := strconv.ParseFloat(s[i:j], 64)
+			if err != nil {
+				return nil, fmt.Errorf("lp: line %d: bad number %q", line, s[i:j])
+			}
+			res = append(res, token{kind: tokNumber, text: s[i:j], num: v, line: line})
+			i = j
+		case isNameStart(c):
+			j := i
+			for j < len(s) && isNameChar(s[j]) {
+				j++
+			}
+			name := s[i:j]
+			if l := strings.ToLower(name); l == "inf" || l == "infinity" {
+				res = append(res, token{kind: tokNumber, text: name, num: math.Inf(1), line: line})
+			} else {
+				res = append(res, token{kind: tokName, text: name, line: line})
+			}
+			i = j
+		default:
+			return nil, fmt.Errorf("lp: line %d: unexpected character %q", line, c)
+		}
+	}
+	return res, nil
+}
+
+// lpReader turns tokens into builder calls, declaring variables on first
+// use.
+type lpReader struct {
+	b    *Builder
+	toks []token
+	pos  int
+}
+
+func (r *lpReader) variable(name string) Var {
+	if v, ok := r.b.Lookup(name); ok {
+		return v
+	}
+	return r.b.Var(name)
+}
+
+func (r *lpReader) peek(offset int) (token, bool) {
+	if r.pos+offset >= len(r.toks) {
+		return token{}, false
+	}
+	return r.toks[r.pos+offset], true
+}
+
+// label consumes "name :" when present.
+func (r *lpReader) label() string {
+	t, ok := r.peek(0)
+	c, ok2 := r.peek(1)
+	if ok && ok2 && t.kind == tokName && c.kind == tokColon {
+		r.pos += 2
+		return t.text
+	}
+	return ""
+}
+
+// expression reads terms until a relation or the end of the tokens.
+func (r *lpReader) expression() (Expr, error) {
+	e := Expr{}
+	first := true
+	for {
+		t, ok := r.peek(0)
+		if !ok || t.kind == tokRelation {
+			return e, nil
+		}
+		// a name followed by a colon starts the next statement
+		if c, ok := r.peek(1); t.kind == tokName && ok && c.kind == tokColon {
+			return e, nil
+		}
+
+		sign := 1.0
+		signed := false
+		for t.kind == tokSign {
+			if t.text == "-" {
+				sign = -sign
+			}
+			signed = true
+			r.pos++
+			if t, ok = r.peek(0); !ok {
+				return e, fmt.Errorf("lp: line %d: expression ends with a sign", r.toks[r.pos-1].line)
+			}
+		}
+		if !first && !signed {
+			return e, fmt.Errorf("lp: line %d: expected + or - before %q", t.line, t.text)
+		}
+
+		coeff := 1.0
+		if t.kind == tokNumber {
+			coeff = t.num
+			r.pos++
+			if s, ok := r.peek(0); ok && s.kind == tokStar {
+				r.pos++
+			}
+			t, ok = r.peek(0)
+			if !ok || t.kind != tokName || r.startsStatement() {
+				e.Const += sign * coeff
+				first = false
+				continue
+			}
+		}
+		if t.kind != tokName {
+			return e, fmt.Errorf("lp: line %d: unexpected %q in expression", t.line, t.text)
+		}
+		r.pos++
+		e = e.AddTerm(sign*coeff, r.variable(t.text))
+		first = false
+	}
+}
+
+func (r *lpReader) startsStatement() bool {
+	t, ok := r.peek(0)
+	c, ok2 := r.peek(1)
+	return ok && ok2 && t.kind == tokName && c.kind == tokColon
+}
+
+func (r *lpReader) number() (float64, error) {
+	sign := 1.0
+	t, ok := r.peek(0)
+	for ok && t.kind == tokSign {
+		if t.text == "-" {
+			sign = -sign
+		}
+		r.pos++
+		t, ok = r.peek(0)
+	}
+	if !ok || t.kind != tokNumber {
+		line := r.toks[len(r.toks)-1].line
+		if ok {
+			line = t.line
+		}
+		return 0, fmt.Errorf("lp: line %d: expected a number", line)
+	}
+	r.pos++
+	return sign * t.num, nil
+}
+
+func (r *lpReader) objective(maximize bool) error {
+	r.b.ObjectiveName = r.label()
+	e, err := r.expression()
+	if err != nil {
+		return err
+	}
+	if r.pos < len(r.toks) {
+		return fmt.Errorf("lp: line %d: unexpected %q in objective", r.toks[r.pos].line, r.toks[r.pos].text)
+	}
+	if maximize {
+		r.b.Maximize(e)
+	} else {
+		r.b.Minimize(e)
+	}
+	return nil
+}
+
+func (r *lpReader) constraints() error {
+	for r.pos < len(r.toks) {
+		name := r.label()
+		lhs, err := r.expression()
+		if err != nil {
+			return err
+		}
+		rel, ok := r.peek(0)
+		if !ok || rel.kind != tokRelation {
+			return fmt.Errorf("lp: line %d: constraint without relation", r.toks[r.pos-1].line)
+		}
+		r.pos++
+		rhs, err := r.number()
+		if err != nil {
+			return err
+		}
+		r.b.Constrain(name, lhs, senseOf(rel.text), rhs)
+	}
+	return nil
+}
+
+func senseOf(rel string) Sense {
+	switch rel {
+	case "<=":
+		return LessEqual
+	case ">=":
+		return GreaterEqual
+	default:
+		return Equal
+	}
+}
+
+// bound reads one line of the bounds section.
+func (r *lpReader) bound() error {
+	line := r.toks[0].line
+	if len(r.toks) == 2 && r.toks[0].kind == tokName && strings.EqualFold(r.toks[1].text, "free") {
+		r.setBounds(r.toks[0].text, math.Inf(-1), math.Inf(1))
+		return nil
+	}
+
+	apply := func(name string, rel string, v float64, numberFirst bool) (float64, float64) {
+		b := r.b.bounds[r.variable(name).index]
+		lo, hi := b.Lower, b.Upper
+		if numberFirst && rel != "=" {
+			// "v <= x" is a lower bound, "v >= x" an upper one
+			if rel == "<=" {
+				rel = ">="
+			} else {
+				rel = "<="
+			}
+		}
+		switch rel {
+		case "<=":
+			hi = v
+		case ">=":
+			lo = v
+		default:
+			lo, hi = v, v
+		}
+		return lo, hi
+	}
+
+	var name string
+	if t := r.toks[0]; t.kind == tokName {
+		name = t.text
+		r.pos = 1
+	} else {
+		v, err := r.number()
+		if err != nil {
+			return err
+		}
+		rel, ok := r.peek(0)
+		n, ok2 := r.peek(1)
+		if !ok || !ok2 || rel.kind != tokRelation || n.kind != tokName {
+			return fmt.Errorf("lp: line %d: malformed bound", line)
+		}
+		name = n.text
+		lo, hi := apply(name, rel.text, v, true)
+		r.setBounds(name, lo, hi)
+		r.pos += 2
+	}
+
+	if r.pos == len(r.toks) {
+		if r.pos == 1 {
+			return fmt.Errorf("lp: line %d: bound for %s has no limit", line, name)
+		}
+		return nil
+	}
+	rel, ok := r.peek(0)
+	if !ok || rel.kind != tokRelation {
+		return fmt.Errorf("lp: line %d: malformed bound", line)
+	}
+	r.pos++
+	v, err := r.number()
+	if err != nil {
+		return err
+	}
+	if r.pos != len(r.toks) {
+		return fmt.Errorf("lp: line %d: unexpected %q after bound", line, r.toks[r.pos].text)
+	}
+	lo, hi := apply(name, rel.text, v, false)
+	r.setBounds(name, lo, hi)
+	return nil
+}
+
+func (r *lpReader) setBounds(name string, lo, hi float64) {
+	v := r.variable(name)
+	r.b.bounds[v.index] = Bound{Lower: lo, Upper: hi}
+}
+
+// ReadLP parses a model in CPLEX LP format.
+func ReadLP(rd io.Reader) (*Builder, error) {
+	b := NewBuilder()
+	current := sectionNone
+	maximize := false
+	var pending []token
+	objectiveSeen := false
+
+	flush := func() error {
+		r := &lpReader{b: b, toks: pending}
+		var err error
+		switch current {
+		case sectionObjective:
+			err = r.objective(maximize)
+			objectiveSeen = true
+		case sectionConstraints:
+			err = r.constraints()
+		}
+		pending = nil
+		return err
+	}
+
+	sc := bufio.NewScanner(rd)
+	for line := 1; sc.Scan(); line++ {
+		text := sc.Text()
+		if k := strings.IndexByte(text, '\\'); k >= 0 {
+			if name, ok := strings.CutPrefix(strings.TrimSpace(text[k+1:]), "Problem name:"); ok {
+				b.Name = strings.TrimSpace(name)
+			}
+			text = text[:k]
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+
+		if s, ok := lpSections[strings.Join(strings.Fields(strings.ToLower(text)), " ")]; ok {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			if s == sectionObjective && objectiveSeen {
+				return nil, fmt.Errorf("lp: line %d: second objective section", line)
+			}
+			current = s
+			maximize = strings.HasPrefix(strings.ToLower(text), "max")
+			if s == sectionEnd {
+				break
+			}
+			continue
+		}
+
+		if current == sectionGenerals || current == sectionBinaries {
+			for _, name := range strings.Fields(text) {
+				v, ok := b.Lookup(name)
+				if !ok {
+					v = b.Var(name)
+				}
+				b.integer[v.index] = true
+				if current == sectionBinaries {
+					b.bounds[v.index] = Bound{Lower: 0, Upper: 1}
+				}
+			}
+			continue
+		}
+
+		toks, err := tokenize(text, line)
+		if err != nil {
+			return nil, err
+		}
+		switch current {
+		case sectionNone:
+			return nil, fmt.Errorf("lp: line %d: content before the objective section", line)
+		case sectionBounds:
+			r := &lpReader{b: b, toks: toks}
+			if err := r.bound(); err != nil {
+				return nil, err
+			}
+		default:
+			pending = append(pending, toks...)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	if !objectiveSeen {
+		return nil, fmt.Errorf("lp: no objective section")
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b, nil
+}
+
+// checkNames applies a format's name rule to the objective, constraint and
+// variable names the writers emit.
+func (b *Builder) checkNames(check func(string) error) error {
+	if err := check(b.objectiveRow()); err != nil {
+		return err
+	}
+	for _, c := range b.constraints {
+		if err := check(c.name); err != nil {
+			return err
+		}
+	}
+	for _, v := range b.vars {
+		if err := check(v.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lpName rejects names that ReadLP would not read back as the same name:
+// names with whitespace, operators, colons or the comment backslash, names
+// starting with a digit or '.', and keywords.
+func lpName(name string) error {
+	ok := name != "" && isNameStart(name[0]) && !strings.ContainsRune(name, '\\')
+	for i := 1; ok && i < len(name); i++ {
+		ok = isNameChar(name[i])
+	}
+	l := strings.ToLower(name)
+	if _, keyword := lpSections[l]; keyword || l == "inf" || l == "infinity" || l == "free" {
+		ok = false
+	}
+	if !ok {
+		return fmt.Errorf("lp: name %q cannot be written in LP format", name)
+	}
+	return nil
+}
+
+// objectiveRow is the name the writers give the objective: ObjectiveName,
+// or obj, prefixed with underscores while a constraint has the same name.
+func (b *Builder) objectiveRow() string {
+	name := b.ObjectiveName
+	if name == "" {
+		name = "obj"
+	}
+	for _, taken := b.conIndex[name]; taken; _, taken = b.conIndex[name] {
+		name = "_" + name
+	}
+	return name
+}
+
+// lpTerms prints coefficients·variables for the LP format, which has no
+// spare constant on the left-hand side.
+func (b *Builder) lpTerms(e Expr) string {
+	s := Expr{vars: e.vars, coeffs: e.coeffs}.String()
+	if len(e.vars) == 0 || s == "0" {
+		// an empty row still needs a variable to be valid LP
+		if len(b.vars) > 0 {
+			return "0 " + b.vars[0].name
+		}
+	}
+	return s
+}
+
+// WriteLP writes the model in CPLEX LP format. Every variable gets an
+// explicit bound so that the file lists all of them; integer variables,
+// binaries included, go to the Generals section. Names the format cannot
+// carry are an error.
+func WriteLP(w io.Writer, b *Builder) error {
+	if b.err != nil {
+		return b.err
+	}
+	if err := b.checkNames(lpName); err != nil {
+		return err
+	}
+	if strings.ContainsAny(b.Name, "\r\n") {
+		return fmt.Errorf("lp: problem name %q spans lines", b.Name)
+	}
+	bw := bufio.NewWriter(w)
+	if b.Name != "" {
+		fmt.Fprintf(bw, "\\Problem name: %s\n", b.Name)
+	}
+	if b.maximize {
+		fmt.Fprintln(bw, "Maximize")
+	} else {
+		fmt.Fprintln(bw, "Minimize")
+	}
+	obj := b.lpTerms(b.objective)
+	switch {
+	case b.objective.Const > 0:
+		obj += " + " + formatNumber(b.objective.Const)
+	case b.objective.Const < 0:
+		obj += " - " + formatNumber(-b.objective.Const)
+	}
+	fmt.Fprintf(bw, " %s: %s\n", b.objectiveRow(), obj)
+
+	fmt.Fprintln(bw, "Subject To")
+	for _, c := range b.constraints {
+		fmt.Fprintf(bw, " %s: %s %s %s\n", c.name, b.lpTerms(c.lhs), c.sense, formatNumber(c.rhs-c.lhs.Const))
+	}
+
+	fmt.Fprintln(bw, "Bounds")
+	for j, v := range b.vars {
+		fmt.Fprintf(bw, " %s\n", formatLPBound(v.name, b.bounds[j]))
+	}
+	if b.HasIntegers() {
+		fmt.Fprintln(bw, "Generals")
+		for j, v := range b.vars {
+			if b.integer[j] {
+				fmt.Fprintf(bw, " %s\n", v.name)
+			}
+		}
+	}
+	fmt.Fprintln(bw, "End")
+	return bw.Flush()
+}
+
+func formatLPBound(name string, bd Bound) string {
+	lower, upper := !math.IsInf(bd.Lower, -1), !math.IsInf(bd.Upper, 1)
+	switch {
+	case !lower && !upper:
+		return name + " free"
+	case !lower:
+		return "-inf <= " + name + " <= " + formatNumber(bd.Upper)
+	default:
+		return formatBound(name, bd)
+	}
+}
+
+// ReadFile reads a model, choosing the format by extension: .mps for free
+// MPS, CPLEX LP otherwise.
+func ReadFile(path string) (*Builder, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if strings.ToLower(filepath.Ext(path)) == ".mps" {
+		return ReadMPS(f)
+	}
+	return ReadLP(f)
+}
+
+func WriteFile(path string, b *Builder) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if strings.ToLower(filepath.Ext(path)) == ".mps" {
+		err = WriteMPS(f, b)
+	} else {
+		err = WriteLP(f, b)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,356 @@
+package lp
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var fixtures = []string{
+	"../lpsolve/data/production.lp",
+	"../lpsolve/data/diet.mps",
+	"../lpsolve/data/projects.lp",
+}
+
+func TestReadLPErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"content before objective", "x + y <= 1\nMaximize\n obj: x\nEnd\n", "before the objective"},
+		{"no objective", "Subject To\n c: x <= 1\nEnd\n", "no objective section"},
+		{"missing objective section", "", "no objective section"},
+		{"second objective", "Maximize\n x\nMinimize\n x\nEnd\n", "second objective"},
+		{"dangling sign", "Maximize\n obj: x +\nEnd\n", "ends with a sign"},
+		{"missing operator", "Maximize\n obj: x y\nEnd\n", "expected + or -"},
+		{"no relation", "Maximize\n obj: x\nSubject To\n c: x + y\nEnd\n", "without relation"},
+		{"no right-hand side", "Maximize\n obj: x\nSubject To\n c: x <=\nEnd\n", "expected a number"},
+		{"bad relation", "Maximize\n obj: x\nSubject To\n c: x <> 1\nEnd\n", "unknown relation"},
+		{"duplicate constraint", "Maximize\n obj: x\nSubject To\n c: x <= 1\n c: x <= 2\nEnd\n", "declared twice"},
+		{"bound without limit", "Maximize\n obj: x\nBounds\n x\nEnd\n", "has no limit"},
+		{"malformed bound", "Maximize\n obj: x\nBounds\n 1 <= 2\nEnd\n", "malformed bound"},
+		{"inverted bounds", "Maximize\n obj: x\nBounds\n x >= 3\n x <= 1\nEnd\n", "lower bound 3 above upper bound 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := ReadLP(strings.NewReader(tt.input))
+			if err == nil {
+				// bounds are checked when the model is built
+				_, err = b.Build()
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("got error %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadMPSErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"missing ENDATA", "NAME t\nROWS\n N obj\n", "missing ENDATA"},
+		{"no objective row", "NAME t\nROWS\n L c\nENDATA\n", "no objective row"},
+		{"unknown row type", "ROWS\n X c\nENDATA\n", "unknown row type"},
+		{"unknown row", "ROWS\n N obj\nCOLUMNS\n    x  c  1\nENDATA\n", "unknown row c"},
+		{"bad number", "ROWS\n N obj\nCOLUMNS\n    x  obj  one\nENDATA\n", "bad number"},
+		{"duplicate row", "ROWS\n N obj\n L c\n G c\nENDATA\n", "declared twice"},
+		{"bound without value", "ROWS\n N obj\nBOUNDS\n UP BND  x\nENDATA\n", "needs a value"},
+		{"unknown bound", "ROWS\n N obj\nBOUNDS\n XX BND  x  1\nENDATA\n", "unsupported bound type"},
+		{"unknown section", "ROWS\n N obj\nRANGES\nENDATA\n", "unsupported section"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadMPS(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("got error %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteRejectsNames(t *testing.T) {
+	tests := []struct {
+		name string
+		lp   bool
+		mps  bool
+	}{
+		{"x", true, true},
+		{"x_1.a", true, true},
+		{"two words", false, false},
+		{"a:b", false, true},
+		{"x+y", false, true},
+		{"x-1", false, true},
+		{"1x", false, true},
+		{".x", false, true},
+		{"end", false, true},
+		{"inf", false, true},
+		{"MARKER", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBuilder()
+			x := b.Var(tt.name)
+			b.LessEqual("c", x.Expr(), 1)
+			b.Maximize(x.Expr())
+			if err := WriteLP(io.Discard, b); (err == nil) != tt.lp {
+				t.Errorf("WriteLP error %v, want ok = %v", err, tt.lp)
+			}
+			if err := WriteMPS(io.Discard, b); (err == nil) != tt.mps {
+				t.Errorf("WriteMPS error %v, want ok = %v", err, tt.mps)
+			}
+
+			// the same rules apply to constraint names
+			b = NewBuilder()
+			x = b.Var("x")
+			b.LessEqual(tt.name, x.Expr(), 1)
+			b.Maximize(x.Expr())
+			if err := WriteLP(io.Discard, b); (err == nil) != tt.lp {
+				t.Errorf("WriteLP error %v for a constraint, want ok = %v", err, tt.lp)
+			}
+			if err := WriteMPS(io.Discard, b); (err == nil) != tt.mps {
+				t.Errorf("WriteMPS error %v for a constraint, want ok = %v", err, tt.mps)
+			}
+		})
+	}
+}
+
+func TestObjectiveConstant(t *testing.T) {
+	tests := []struct {
+		objective string
+		want      float64
+	}{
+		{"x + 7", 8},
+		{"x - 7", -6},
+		{"-7 + x", -6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.objective, func(t *testing.T) {
+			input := "Minimize\n cost: " + tt.objective + "\nSubject To\n c: x >= 1\nEnd\n"
+			b, err := ReadLP(strings.NewReader(input))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var mps bytes.Buffer
+			if err := WriteMPS(&mps, b); err != nil {
+				t.Fatal(err)
+			}
+			// the MPS objective RHS is minus the constant
+			want := "RHS  cost  " + formatNumber(-(tt.want - 1))
+			if !strings.Contains(mps.String(), want) {
+				t.Fatalf("MPS output lacks %q:\n%s", want, mps.String())
+			}
+
+			back, err := ReadMPS(&mps)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, m := range []*Builder{b, back} {
+				sol, err := m.Solve()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if math.Abs(sol.Objective-tt.want) > 1e-9 {
+					t.Errorf("objective %v, want %v", sol.Objective, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestReadLPSections(t *testing.T) {
+	input := `\Problem name: sections
+MAXIMIZE
+ value: 2 x + 3y
+  - z + w
+st
+ first: x + y
+   + z <= 10
+ -x + 2 w >= -4
+Bounds
+ -inf <= x <= 8
+ y free
+ -2 <= z <= 3
+ z >= -1
+ w = 2
+Generals
+ y
+Binaries
+ b
+End
+`
+	b, err := ReadLP(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.Name != "sections" || b.ObjectiveName != "value" {
+		t.Errorf("names %q/%q, want sections/value", b.Name, b.ObjectiveName)
+	}
+	if got, want := b.ConstraintNames(), []string{"first", "c2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("constraints %v, want %v", got, want)
+	}
+	if !m.Maximize || !reflect.DeepEqual(m.Objective, []float64{2, 3, -1, 1, 0}) {
+		t.Errorf("objective %v (maximize %v)", m.Objective, m.Maximize)
+	}
+	if c := m.Constraints[1]; c.Sense != GreaterEqual || c.RHS != -4 || !reflect.DeepEqual(c.Coeffs, []float64{-1, 0, 0, 2, 0}) {
+		t.Errorf("second constraint %+v", c)
+	}
+
+	inf := math.Inf(1)
+	wantBounds := []Bound{{-inf, 8}, {-inf, inf}, {-1, 3}, {2, 2}, {0, 1}}
+	if !reflect.DeepEqual(m.Bounds, wantBounds) {
+		t.Errorf("bounds %v, want %v", m.Bounds, wantBounds)
+	}
+	if want := []bool{false, true, false, false, true}; !reflect.DeepEqual(m.Integer, want) {
+		t.Errorf("integer %v, want %v", m.Integer, want)
+	}
+}
+
+func TestReadMPSBounds(t *testing.T) {
+	input := `NAME bounds
+OBJSENSE MAX
+ROWS
+ N  profit
+ L  c
+COLUMNS
+    MARKER  'MARKER'  'INTORG'
+    n  profit  1  c  1
+    MARKER  'MARKER'  'INTEND'
+    x  profit  1  c  1
+    f  profit  0
+    m  profit  0
+    u  profit  0
+    l  profit  0
+RHS
+    RHS  c  4
+BOUNDS
+ UP BND  x  3
+ LO BND  x  -1
+ FR BND  f
+ MI BND  m
+ UI BND  u  5
+ LI BND  l  2
+ BV BND  v
+ENDATA
+`
+	b, err := ReadMPS(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.Maximize || b.Name != "bounds" || b.ObjectiveName != "profit" {
+		t.Errorf("maximize %v, names %q/%q", m.Maximize, b.Name, b.ObjectiveName)
+	}
+
+	inf := math.Inf(1)
+	wantBounds := []Bound{{0, inf}, {-1, 3}, {-inf, inf}, {-inf, inf}, {0, 5}, {2, inf}, {0, 1}}
+	if !reflect.DeepEqual(m.Bounds, wantBounds) {
+		t.Errorf("bounds %v, want %v", m.Bounds, wantBounds)
+	}
+	if want := []bool{true, false, false, false, true, true, true}; !reflect.DeepEqual(m.Integer, want) {
+		t.Errorf("integer %v, want %v", m.Integer, want)
+	}
+}
+
+// sameModel compares everything the formats carry.
+func sameModel(t *testing.T, got, want *Builder) {
+	t.Helper()
+	gm, err := got.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wm, err := want.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gm, wm) {
+		t.Errorf("model %+v, want %+v", gm, wm)
+	}
+	if got.Name != want.Name || got.ObjectiveName != want.ObjectiveName {
+		t.Errorf("names %q/%q, want %q/%q", got.Name, got.ObjectiveName, want.Name, want.ObjectiveName)
+	}
+	if !reflect.DeepEqual(got.ConstraintNames(), want.ConstraintNames()) {
+		t.Errorf("constraints %v, want %v", got.ConstraintNames(), want.ConstraintNames())
+	}
+	if !reflect.DeepEqual(got.Vars(), want.Vars()) {
+		t.Errorf("variables %v, want %v", got.Vars(), want.Vars())
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	writers := []struct {
+		name  string
+		write func(*bytes.Buffer, *Builder) error
+		read  func(*bytes.Buffer) (*Builder, error)
+	}{
+		{"lp", func(w *bytes.Buffer, b *Builder) error { return WriteLP(w, b) }, func(r *bytes.Buffer) (*Builder, error) { return ReadLP(r) }},
+		{"mps", func(w *bytes.Buffer, b *Builder) error { return WriteMPS(w, b) }, func(r *bytes.Buffer) (*Builder, error) { return ReadMPS(r) }},
+	}
+	for _, path := range fixtures {
+		orig, err := ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, first := range writers {
+			for _, second := range writers {
+				t.Run(path+"/"+first.name+"-"+second.name, func(t *testing.T) {
+					var buf bytes.Buffer
+					if err := first.write(&buf, orig); err != nil {
+						t.Fatal(err)
+					}
+					mid, err := first.read(&buf)
+					if err != nil {
+						t.Fatal(err)
+					}
+					buf.Reset()
+					if err := second.write(&buf, mid); err != nil {
+						t.Fatal(err)
+					}
+					back, err := second.read(&buf)
+					if err != nil {
+						t.Fatal(err)
+					}
+					sameModel(t, back, orig)
+				})
+			}
+		}
+	}
+}
+
+func TestFixtureOptima(t *testing.T) {
+	tests := []struct {
+		path string
+		want float64
+	}{
+		{"../lpsolve/data/production.lp", 36},
+		{"../lpsolve/data/diet.mps", 26.952380952380953},
+	}
+	for _, tt := range tests {
+		b, err := ReadFile(tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sol, err := b.Solve()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(sol.Objective-tt.want) > 1e-6 {
+			t.Errorf("%s: objective %v, want %v", tt.path, sol.Objective, tt.want)
+		}
+	}
+}
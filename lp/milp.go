@@ -0,0 +1,353 @@
+package lp
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrNodeLimit is returned when the node limit stops the search before any
+// integer solution is found.
+var ErrNodeLimit = errors.New("lp: node limit reached without an integer solution")
+
+const (
+	defaultIntTol    = 1e-6
+	defaultGapTol    = 1e-6
+	defaultNodeLimit = 10000
+)
+
+// NodeSelection picks the next open node of the branch-and-bound tree.
+type NodeSelection int
+
+const (
+	// BestBound explores the node with the best relaxation first, which
+	// proves optimality with the fewest nodes.
+	BestBound NodeSelection = iota
+	// DepthFirst dives to a leaf first, which finds incumbents early and
+	// keeps few nodes open.
+	DepthFirst
+)
+
+func (s NodeSelection) String() string {
+	switch s {
+	case BestBound:
+		return "best-bound"
+	case DepthFirst:
+		return "depth-first"
+	default:
+		return "?"
+	}
+}
+
+// Incumbent is an integer solution found during the search.
+type Incumbent struct {
+	X         []float64
+	Objective float64
+	// Node is the number of nodes solved when the solution was found and
+	// Bound the best objective still possible at that moment.
+	Node  int
+	Bound float64
+}
+
+// MILPOptions tunes SolveMILP. Zero values select best-bound search, a
+// limit of 10000 nodes, an integrality tolerance of 1e-6 and a relative
+// gap of 1e-6; a negative NodeLimit removes the limit, which may never
+// return when integer variables are unbounded. IntTol decides when a value
+// counts as integral; GapTol prunes nodes whose relaxation cannot beat the
+// incumbent by more than that share of its objective. OnIncumbent is
+// called for every improving integer solution.
+type MILPOptions struct {
+	Selection   NodeSelection
+	NodeLimit   int
+	IntTol      float64
+	GapTol      float64
+	OnIncumbent func(Incumbent)
+}
+
+// MILPResult is the best integer solution found. Optimal is false when the
+// node limit stopped the search; Bound then limits how much better the true
+// optimum can be. Otherwise Objective is optimal up to GapTol.
+type MILPResult struct {
+	X         []float64
+	Objective float64
+	Bound     float64
+	Nodes     int
+	Optimal   bool
+}
+
+// Gap is the relative distance between Objective and Bound.
+func (r MILPResult) Gap() float64 {
+	return math.Abs(r.Bound-r.Objective) / math.Max(1, math.Abs(r.Objective))
+}
+
+// SetInteger marks variable j as integer.
+func (m *Model) SetInteger(j int) {
+	for len(m.Integer) < len(m.Objective) {
+		m.Integer = append(m.Integer, false)
+	}
+	m.Integer[j] = true
+}
+
+// SetBinary marks variable j as integer in [0, 1].
+func (m *Model) SetBinary(j int) {
+	m.SetInteger(j)
+	m.SetBounds(j, 0, 1)
+}
+
+func (m *Model) integer(j int) bool {
+	return j < len(m.Integer) && m.Integer[j]
+}
+
+// round returns a copy of x with the integer variables rounded.
+func (m *Model) round(x []float64) []float64 {
+	res := make([]float64, len(x))
+	for j, v := range x {
+		if m.integer(j) {
+			v = math.Round(v)
+		}
+		res[j] = v
+	}
+	return res
+}
+
+// feasible reports whether x satisfies the bounds, constraints and
+// integrality of m within tol.
+func (m *Model) feasible(x []float64, tol float64) bool {
+	for j, v := range x {
+		bd := m.bound(j)
+		if v < bd.Lower-tol || v > bd.Upper+tol {
+			return false
+		}
+		if m.integer(j) && math.Abs(v-math.Round(v)) > tol {
+			return false
+		}
+	}
+	for _, c := range m.Constraints {
+		lhs := dot(c.Coeffs, x)
+		switch {
+		case c.Sense == LessEqual && lhs > c.RHS+tol,
+			c.Sense == GreaterEqual && lhs < c.RHS-tol,
+			c.Sense == Equal && math.Abs(lhs-c.RHS) > tol:
+			return false
+		}
+	}
+	return true
+}
+
+// node is a subproblem: the model with tightened bounds and the objective
+// of its LP relaxation, negated for maximization so that lower is better.
+type node struct {
+	bounds []Bound
+	res    Result
+	key    float64
+	depth  int
+}
+
+// nodeQueue is a min-heap on the relaxation key, deeper nodes first on
+// ties.
+type nodeQueue []*node
+
+func (q nodeQueue) Len() int { return len(q) }
+func (q nodeQueue) Less(i, j int) bool {
+	if q[i].key != q[j].key {
+		return q[i].key < q[j].key
+	}
+	return q[i].depth > q[j].depth
+}
+func (q nodeQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *nodeQueue) Push(x any)   { *q = append(*q, x.(*node)) }
+func (q *nodeQueue) Pop() any {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
+
+// SolveMILP solves the model with the variables marked by Integer restricted
+// to integers, by branch and bound on the LP relaxation. Each node branches
+// on the most fractional variable into x <= floor(v) and x >= ceil(v).
+// Infeasible relaxations are pruned; an unbounded root wraps ErrUnbounded.
+func (m *Model) SolveMILP(opts MILPOptions) (MILPResult, error) {
+	if err := m.validate(); err != nil {
+		return MILPResult{}, err
+	}
+	if len(m.Integer) != 0 && len(m.Integer) != len(m.Objective) {
+		return MILPResult{}, fmt.Errorf("lp: %d integer flags for %d variables", len(m.Integer), len(m.Objective))
+	}
+	tol := opts.IntTol
+	if tol <= 0 {
+		tol = defaultIntTol
+	}
+	gap := opts.GapTol
+	if gap <= 0 {
+		gap = defaultGapTol
+	}
+	limit := opts.NodeLimit
+	if limit == 0 {
+		limit = defaultNodeLimit
+	}
+	sign := 1.0
+	if m.Maximize {
+		sign = -1
+	}
+
+	root := make([]Bound, len(m.Objective))
+	for j := range root {
+		root[j] = m.bound(j)
+		// integer variables only take the integers inside their bounds
+		if m.integer(j) {
+			root[j] = Bound{Lower: math.Ceil(root[j].Lower - tol), Upper: math.Floor(root[j].Upper + tol)}
+			if root[j].Lower > root[j].Upper {
+				return MILPResult{}, fmt.Errorf("%w: no integer between the bounds of variable %d", ErrInfeasible, j)
+			}
+		}
+	}
+
+	// the nodes need the relaxed optimum only
+	sub := *m
+	sub.Sensitivity = false
+	relax := func(bounds []Bound) (*node, error) {
+		sub.Bounds = bounds
+		res, err := sub.Solve()
+		if err != nil {
+			return nil, err
+		}
+		return &node{bounds: bounds, res: res, key: sign * res.Objective}, nil
+	}
+
+	result := MILPResult{}
+	best := math.Inf(1) // key of the incumbent
+	var queue nodeQueue
+	var stack []*node
+	push := func(n *node) {
+		if opts.Selection == DepthFirst {
+			stack = append(stack, n)
+		} else {
+			heap.Push(&queue, n)
+		}
+	}
+	open := func() int {
+		return len(queue) + len(stack)
+	}
+	// bound is the best key among the open nodes and the incumbent
+	bound := func() float64 {
+		b := best
+		for _, n := range queue {
+			b = math.Min(b, n.key)
+		}
+		for _, n := range stack {
+			b = math.Min(b, n.key)
+		}
+		return b
+	}
+	// accept makes x the incumbent if it improves on it; floor is the key
+	// of the node being branched, which is no longer open
+	accept := func(x []float64, floor float64) {
+		obj := dot(m.Objective, x)
+		if sign*obj >= best {
+			return
+		}
+		best = sign * obj
+		result.X, result.Objective = x, obj
+		if opts.OnIncumbent != nil {
+			opts.OnIncumbent(Incumbent{
+				X:         append([]float64{}, x...),
+				Objective: obj,
+				Node:      result.Nodes,
+				Bound:     sign * math.Min(bound(), floor),
+			})
+		}
+	}
+	// heuristic rounds a relaxed solution, which finds incumbents the
+	// search may never reach, e.g. with unbounded integer variables
+	heuristic := func(x []float64, floor float64) {
+		if x = m.round(x); m.feasible(x, tol) {
+			accept(x, floor)
+		}
+	}
+
+	first, err := relax(root)
+	if err != nil {
+		return MILPResult{}, err
+	}
+	result.Nodes = 1
+	heuristic(first.res.X, first.key)
+	if first.key < best {
+		push(first)
+	}
+
+	for open() > 0 {
+		var cur *node
+		if opts.Selection == DepthFirst {
+			cur = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+		} else {
+			cur = heap.Pop(&queue).(*node)
+		}
+		if !math.IsInf(best, 1) && cur.key >= best-gap*math.Max(1, math.Abs(best)) {
+			continue
+		}
+
+		branch, frac := -1, 0.0
+		for j, v := range cur.res.X {
+			if !m.integer(j) {
+				continue
+			}
+			if f := math.Abs(v - math.Round(v)); f > tol && f > frac {
+				branch, frac = j, f
+			}
+		}
+
+		if branch < 0 {
+			accept(m.round(cur.res.X), cur.key)
+			continue
+		}
+
+		// both children must fit in the limit, otherwise cur stays open so
+		// that the bound still covers it
+		if limit > 0 && result.Nodes+2 > limit {
+			push(cur)
+			break
+		}
+
+		v := cur.res.X[branch]
+		down := append([]Bound{}, cur.bounds...)
+		down[branch].Upper = math.Floor(v)
+		up := append([]Bound{}, cur.bounds...)
+		up[branch].Lower = math.Ceil(v)
+		// depth-first dives into the side nearer to the relaxed value
+		children := [][]Bound{up, down}
+		if v-math.Floor(v) >= 0.5 {
+			children = [][]Bound{down, up}
+		}
+		for _, bounds := range children {
+			child, err := relax(bounds)
+			result.Nodes++
+			switch {
+			case errors.Is(err, ErrInfeasible):
+				continue
+			case err != nil:
+				return MILPResult{}, err
+			}
+			child.depth = cur.depth + 1
+			heuristic(child.res.X, cur.key)
+			if child.key < best {
+				push(child)
+			}
+		}
+	}
+
+	result.Optimal = open() == 0
+	result.Bound = sign * bound()
+	if result.X == nil {
+		if result.Optimal {
+			return MILPResult{}, fmt.Errorf("%w: no integer solution", ErrInfeasible)
+		}
+		return MILPResult{}, fmt.Errorf("%w after %d nodes", ErrNodeLimit, result.Nodes)
+	}
+	if result.Optimal {
+		result.Bound = result.Objective
+	}
+	return result, nil
+}
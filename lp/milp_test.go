@@ -0,0 +1,139 @@
+package lp
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// knapsack is a 0/1 knapsack with capacity 13 and integer optimum 28; its
+// relaxation is 30.
+func knapsack() *Model {
+	m := &Model{
+		Objective:   []float64{10, 13, 7, 8, 5},
+		Maximize:    true,
+		Constraints: []Constraint{{Coeffs: []float64{5, 6, 4, 3, 2}, Sense: LessEqual, RHS: 13}},
+	}
+	for j := range m.Objective {
+		m.SetBinary(j)
+	}
+	return m
+}
+
+func TestSolveMILPKnapsack(t *testing.T) {
+	for _, sel := range []NodeSelection{BestBound, DepthFirst} {
+		t.Run(sel.String(), func(t *testing.T) {
+			m := knapsack()
+			res, err := m.SolveMILP(MILPOptions{Selection: sel})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !res.Optimal || res.Objective != 28 || res.Bound != 28 {
+				t.Errorf("objective %v, bound %v, optimal %v; want 28, 28, true", res.Objective, res.Bound, res.Optimal)
+			}
+			if !m.feasible(res.X, 1e-6) || dot(m.Objective, res.X) != res.Objective {
+				t.Errorf("solution %v is not a feasible point with objective %v", res.X, res.Objective)
+			}
+		})
+	}
+}
+
+func TestSolveMILPGapTol(t *testing.T) {
+	m := knapsack()
+	exact, err := m.SolveMILP(MILPOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	loose, err := m.SolveMILP(MILPOptions{GapTol: 0.2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a node is pruned unless it can beat the incumbent by 20%
+	if !loose.Optimal || loose.Objective*1.2 < 28 || loose.Nodes > exact.Nodes {
+		t.Errorf("objective %v after %d nodes (exact search %d), want within 20%% of 28", loose.Objective, loose.Nodes, exact.Nodes)
+	}
+}
+
+func TestSolveMILPInfeasible(t *testing.T) {
+	tests := []struct {
+		name string
+		m    *Model
+	}{
+		{"odd parity", &Model{
+			Objective:   []float64{1},
+			Constraints: []Constraint{{Coeffs: []float64{2}, Sense: Equal, RHS: 1}},
+			Bounds:      []Bound{{0, 10}},
+			Integer:     []bool{true},
+		}},
+		{"no integer in bounds", &Model{
+			Objective: []float64{1},
+			Bounds:    []Bound{{0.2, 0.8}},
+			Integer:   []bool{true},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.m.SolveMILP(MILPOptions{})
+			if !errors.Is(err, ErrInfeasible) {
+				t.Fatalf("got error %v, want ErrInfeasible", err)
+			}
+		})
+	}
+}
+
+func TestSolveMILPNodeLimit(t *testing.T) {
+	m := knapsack()
+	res, err := m.SolveMILP(MILPOptions{Selection: DepthFirst, NodeLimit: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Optimal || res.Nodes > 7 {
+		t.Errorf("optimal %v after %d nodes, want a stopped search within 7", res.Optimal, res.Nodes)
+	}
+	if !m.feasible(res.X, 1e-6) || res.Objective > 28 {
+		t.Errorf("incumbent %v with objective %v is not feasible", res.X, res.Objective)
+	}
+	// the bound of a maximization covers the true optimum
+	if res.Bound < 28 || res.Bound > 30 {
+		t.Errorf("bound %v outside [28, 30]", res.Bound)
+	}
+
+	// the relaxation y = 4/3 rounds to an infeasible point
+	eq := &Model{
+		Objective:   []float64{0, 1},
+		Maximize:    true,
+		Constraints: []Constraint{{Coeffs: []float64{1, 3}, Sense: Equal, RHS: 4}},
+		Integer:     []bool{true, true},
+	}
+	_, err = eq.SolveMILP(MILPOptions{NodeLimit: 1})
+	if !errors.Is(err, ErrNodeLimit) {
+		t.Errorf("got error %v, want ErrNodeLimit", err)
+	}
+}
+
+// Unbounded integers keep every relaxation at -1.5 while the integer
+// optimum is -1, so only the node limit ends the search; rounding the
+// relaxations supplies the incumbent.
+func TestSolveMILPUnboundedIntegers(t *testing.T) {
+	inf := math.Inf(1)
+	m := &Model{
+		Objective:   []float64{1, 1},
+		Constraints: []Constraint{{Coeffs: []float64{2, 2}, Sense: GreaterEqual, RHS: -3}},
+		Bounds:      []Bound{{-inf, inf}, {-inf, inf}},
+		Integer:     []bool{true, true},
+	}
+	for _, sel := range []NodeSelection{BestBound, DepthFirst} {
+		t.Run(sel.String(), func(t *testing.T) {
+			res, err := m.SolveMILP(MILPOptions{Selection: sel, NodeLimit: 200})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Optimal || res.Objective != -1 || !m.feasible(res.X, 1e-6) {
+				t.Errorf("incumbent %v with objective %v (optimal %v), want a feasible -1", res.X, res.Objective, res.Optimal)
+			}
+			if res.Bound > -1.5+1e-9 {
+				t.Errorf("bound %v, want -1.5", res.Bound)
+			}
+		})
+	}
+}
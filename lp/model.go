@@ -0,0 +1,315 @@
+package lp
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/willauld/lpsimplex"
+)
+
+var (
+	ErrInfeasible     = errors.New("lp: problem is infeasible")
+	ErrUnbounded      = errors.New("lp: problem is unbounded")
+	ErrIterationLimit = errors.New("lp: iteration limit reached")
+)
+
+const (
+	defaultMaxIter = 1000
+	defaultTol     = 1e-9
+)
+
+type Sense int
+
+const (
+	LessEqual Sense = iota
+	GreaterEqual
+	Equal
+)
+
+func (s Sense) String() string {
+	switch s {
+	case LessEqual:
+		return "<="
+	case GreaterEqual:
+		return ">="
+	case Equal:
+		return "="
+	default:
+		return "?"
+	}
+}
+
+// Constraint is Coeffs · x (Sense) RHS.
+type Constraint struct {
+	Coeffs []float64
+	Sense  Sense
+	RHS    float64
+}
+
+// Bound limits one variable; infinite bounds use math.Inf.
+type Bound struct {
+	Lower float64
+	Upper float64
+}
+
+// Model is a linear program over len(Objective) variables. Variables without
+// an explicit bound are non-negative. Zero MaxIter and Tol take the defaults.
+// Integer marks the variables SolveMILP keeps integral; Solve ignores it and
+// solves the relaxation. Sensitivity asks Solve for the duals, reduced costs
+// and RHS ranges, which cost a basis factorisation on top of the solve.
+type Model struct {
+	Objective   []float64
+	Maximize    bool
+	Constraints []Constraint
+	Bounds      []Bound
+	Integer     []bool
+	Sensitivity bool
+	MaxIter     int
+	Tol         float64
+}
+
+type Range struct {
+	Lower float64
+	Upper float64
+}
+
+// Result of an optimal solve. For every constraint Slack is RHS - Coeffs·x,
+// Duals the shadow price (change of the objective per unit of RHS) and
+// RHSRanges the interval the RHS can move in before the optimal basis
+// changes, the other RHS fixed. ReducedCosts are the objective change per
+// unit of each variable moved away from its bound. The last three are only
+// filled when Model.Sensitivity is set.
+type Result struct {
+	X            []float64
+	Objective    float64
+	Iterations   int
+	Slack        []float64
+	Duals        []float64
+	ReducedCosts []float64
+	RHSRanges    []Range
+}
+
+func NewModel(objective []float64, maximize bool) *Model {
+	return &Model{Objective: objective, Maximize: maximize}
+}
+
+// AddConstraint appends a constraint and returns its index.
+func (m *Model) AddConstraint(coeffs []float64, sense Sense, rhs float64) int {
+	m.Constraints = append(m.Constraints, Constraint{Coeffs: coeffs, Sense: sense, RHS: rhs})
+	return len(m.Constraints) - 1
+}
+
+func (m *Model) SetBounds(j int, lower, upper float64) {
+	for len(m.Bounds) < len(m.Objective) {
+		m.Bounds = append(m.Bounds, Bound{Lower: 0, Upper: math.Inf(1)})
+	}
+	m.Bounds[j] = Bound{Lower: lower, Upper: upper}
+}
+
+func (m *Model) SetFree(j int) {
+	m.SetBounds(j, math.Inf(-1), math.Inf(1))
+}
+
+func (m *Model) bound(j int) Bound {
+	if j < len(m.Bounds) {
+		return m.Bounds[j]
+	}
+	return Bound{Lower: 0, Upper: math.Inf(1)}
+}
+
+func (m *Model) validate() error {
+	n := len(m.Objective)
+	if n == 0 {
+		return fmt.Errorf("lp: model has no variables")
+	}
+	if len(m.Bounds) != 0 && len(m.Bounds) != n {
+		return fmt.Errorf("lp: %d bounds for %d variables", len(m.Bounds), n)
+	}
+	for j := range m.Bounds {
+		if b := m.Bounds[j]; b.Lower > b.Upper {
+			return fmt.Errorf("lp: variable %d has lower bound %v above upper bound %v", j, b.Lower, b.Upper)
+		}
+	}
+	for i, c := range m.Constraints {
+		if len(c.Coeffs) != n {
+			return fmt.Errorf("lp: constraint %d has %d coefficients, expected %d", i, len(c.Coeffs), n)
+		}
+		if c.Sense < LessEqual || c.Sense > Equal {
+			return fmt.Errorf("lp: constraint %d has unknown sense %d", i, c.Sense)
+		}
+	}
+	return nil
+}
+
+// Solve runs lpsimplex and, when m.Sensitivity is set, derives the
+// sensitivity report from the optimal basis. Failures wrap ErrInfeasible,
+// ErrUnbounded or ErrIterationLimit; a report that cannot be computed is an
+// error as well.
+func (m *Model) Solve() (Result, error) {
+	if err := m.validate(); err != nil {
+		return Result{}, err
+	}
+
+	maxIter, tol := m.MaxIter, m.Tol
+	if maxIter <= 0 {
+		maxIter = defaultMaxIter
+	}
+	if tol <= 0 {
+		tol = defaultTol
+	}
+
+	sf := m.standardForm()
+	optRes := lpsimplex.LPSimplex(sf.c, sf.Aub, sf.bub, sf.Aeq, sf.beq, nil, nil, false, maxIter, tol, false)
+	if !optRes.Success {
+		return Result{}, statusError(optRes.Status, optRes.Message)
+	}
+	if len(optRes.X) < len(sf.c) {
+		return Result{}, fmt.Errorf("lp: lpsimplex returned %d values for %d variables", len(optRes.X), len(sf.c))
+	}
+
+	res := Result{X: sf.recover(optRes.X), Iterations: optRes.Nitr}
+	for j, v := range res.X {
+		res.Objective += m.Objective[j] * v
+	}
+	res.Slack = make([]float64, len(m.Constraints))
+	for i, con := range m.Constraints {
+		res.Slack[i] = con.RHS - dot(con.Coeffs, res.X)
+	}
+
+	if m.Sensitivity {
+		if err := m.sensitivity(&res); err != nil {
+			return Result{}, err
+		}
+	}
+	return res, nil
+}
+
+// substitution expresses a model variable through non-negative columns of
+// the standard form: x = base + sign·x[pos] - x[neg], neg only for free
+// variables.
+type substitution struct {
+	base float64
+	sign float64
+	pos  int
+	neg  int
+}
+
+// standardForm is the model rewritten for lpsimplex with x >= 0 only.
+// lpsimplex's own bound handling mutates its inputs and does not support
+// free variables, so bounds are substituted here instead.
+type standardForm struct {
+	c     []float64
+	Aub   [][]float64
+	bub   []float64
+	Aeq   [][]float64
+	beq   []float64
+	subst []substitution
+}
+
+func (m *Model) standardForm() standardForm {
+	n := len(m.Objective)
+	sf := standardForm{subst: make([]substitution, n)}
+	cols := 0
+	var upper []int // variables whose shifted upper bound becomes a row
+	for j := 0; j < n; j++ {
+		b := m.bound(j)
+		switch {
+		case !math.IsInf(b.Lower, -1):
+			sf.subst[j] = substitution{base: b.Lower, sign: 1, pos: cols, neg: -1}
+			if !math.IsInf(b.Upper, 1) {
+				upper = append(upper, j)
+			}
+		case !math.IsInf(b.Upper, 1):
+			sf.subst[j] = substitution{base: b.Upper, sign: -1, pos: cols, neg: -1}
+		default:
+			sf.subst[j] = substitution{sign: 1, pos: cols, neg: cols + 1}
+			cols++
+		}
+		cols++
+	}
+
+	// lpsimplex minimises, so negate objective for maximization
+	sf.c = sf.row(m.Objective, cols)
+	if m.Maximize {
+		for k := range sf.c {
+			sf.c[k] = -sf.c[k]
+		}
+	}
+
+	for _, con := range m.Constraints {
+		row := sf.row(con.Coeffs, cols)
+		rhs := con.RHS
+		for j, v := range con.Coeffs {
+			rhs -= v * sf.subst[j].base
+		}
+		switch con.Sense {
+		case LessEqual:
+			sf.Aub = append(sf.Aub, row)
+			sf.bub = append(sf.bub, rhs)
+		case GreaterEqual:
+			for k := range row {
+				row[k] = -row[k]
+			}
+			sf.Aub = append(sf.Aub, row)
+			sf.bub = append(sf.bub, -rhs)
+		case Equal:
+			sf.Aeq = append(sf.Aeq, row)
+			sf.beq = append(sf.beq, rhs)
+		}
+	}
+
+	for _, j := range upper {
+		row := make([]float64, cols)
+		row[sf.subst[j].pos] = 1
+		b := m.bound(j)
+		sf.Aub = append(sf.Aub, row)
+		sf.bub = append(sf.bub, b.Upper-b.Lower)
+	}
+	return sf
+}
+
+// row maps model coefficients onto the standard form columns.
+func (sf standardForm) row(coeffs []float64, cols int) []float64 {
+	row := make([]float64, cols)
+	for j, v := range coeffs {
+		s := sf.subst[j]
+		row[s.pos] += s.sign * v
+		if s.neg >= 0 {
+			row[s.neg] -= v
+		}
+	}
+	return row
+}
+
+func (sf standardForm) recover(x []float64) []float64 {
+	res := make([]float64, len(sf.subst))
+	for j, s := range sf.subst {
+		res[j] = s.base + s.sign*x[s.pos]
+		if s.neg >= 0 {
+			res[j] -= x[s.neg]
+		}
+	}
+	return res
+}
+
+func statusError(status int, message string) error {
+	switch status {
+	case 1:
+		return fmt.Errorf("%w: %s", ErrIterationLimit, message)
+	case 2:
+		return fmt.Errorf("%w: %s", ErrInfeasible, message)
+	case 3:
+		return fmt.Errorf("%w: %s", ErrUnbounded, message)
+	default:
+		return fmt.Errorf("lp: lpsimplex failed: %s (status=%d)", message, status)
+	}
+}
+
+func dot(a, b []float64) float64 {
+	s := 0.0
+	for i := range a {
+		s += a[i] * b[i]
+	}
+	return s
+}
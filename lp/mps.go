@@ -0,0 +1,388 @@
+package lp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Free MPS format: whitespace separated fields, names without spaces.
+//
+//	NAME          example
+//	OBJSENSE
+//	    MAX
+//	ROWS
+//	 N  obj
+//	 L  c1
+//	COLUMNS
+//	    MARKER  'MARKER'  'INTORG'
+//	    x  obj  3  c1  1
+//	    MARKER  'MARKER'  'INTEND'
+//	RHS
+//	    RHS  c1  4
+//	BOUNDS
+//	 UP BND  x  4
+//	ENDATA
+//
+// The RHS of the objective row is minus the objective constant. Columns
+// between the INTORG and INTEND markers are integer, as are those with BV,
+// LI or UI bounds. RANGES are not supported.
+
+type mpsRow struct {
+	sense  Sense
+	coeffs map[int]float64
+	rhs    float64
+}
+
+// ReadMPS parses a model in free MPS format. The first N row is the
+// objective; further N rows are ignored.
+func ReadMPS(rd io.Reader) (*Builder, error) {
+	b := NewBuilder()
+	section := ""
+	objective := ""
+	objCoeffs := map[int]float64{}
+	objConst := 0.0
+	rows := []string{}
+	rowData := map[string]*mpsRow{}
+	ignored := map[string]bool{}
+	integer := false
+
+	sc := bufio.NewScanner(rd)
+	line := 0
+	fail := func(format string, args ...any) error {
+		return fmt.Errorf("mps: line %d: "+format, append([]any{line}, args...)...)
+	}
+	number := func(s string) (float64, error) {
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fail("bad number %q", s)
+		}
+		return v, nil
+	}
+
+	for sc.Scan() {
+		line++
+		text := sc.Text()
+		if strings.HasPrefix(text, "*") || strings.TrimSpace(text) == "" {
+			continue
+		}
+		fields := strings.Fields(text)
+
+		// section headers start in the first column
+		if text[0] != ' ' && text[0] != '\t' {
+			section = strings.ToUpper(fields[0])
+			switch section {
+			case "NAME":
+				if len(fields) > 1 {
+					b.Name = fields[1]
+				}
+			case "OBJSENSE":
+				if len(fields) > 1 {
+					if err := mpsSense(b, fields[1]); err != nil {
+						return nil, fail("%v", err)
+					}
+				}
+			case "ROWS", "COLUMNS", "RHS", "BOUNDS":
+			case "ENDATA":
+				return finishMPS(b, objective, objCoeffs, objConst, rows, rowData)
+			default:
+				return nil, fail("unsupported section %s", section)
+			}
+			continue
+		}
+
+		switch section {
+		case "OBJSENSE":
+			if err := mpsSense(b, fields[0]); err != nil {
+				return nil, fail("%v", err)
+			}
+
+		case "ROWS":
+			if len(fields) != 2 {
+				return nil, fail("expected row type and name")
+			}
+			name := fields[1]
+			if _, ok := rowData[name]; ok || name == objective {
+				return nil, fail("row %s declared twice", name)
+			}
+			switch strings.ToUpper(fields[0]) {
+			case "N":
+				if objective == "" {
+					objective = name
+					b.ObjectiveName = name
+				} else {
+					ignored[name] = true
+				}
+			case "L":
+				rowData[name] = &mpsRow{sense: LessEqual, coeffs: map[int]float64{}}
+				rows = append(rows, name)
+			case "G":
+				rowData[name] = &mpsRow{sense: GreaterEqual, coeffs: map[int]float64{}}
+				rows = append(rows, name)
+			case "E":
+				rowData[name] = &mpsRow{sense: Equal, coeffs: map[int]float64{}}
+				rows = append(rows, name)
+			default:
+				return nil, fail("unknown row type %s", fields[0])
+			}
+
+		case "COLUMNS":
+			if len(fields) == 3 && strings.Trim(fields[1], "'") == "MARKER" {
+				switch strings.Trim(fields[2], "'") {
+				case "INTORG":
+					integer = true
+				case "INTEND":
+					integer = false
+				default:
+					return nil, fail("unknown marker %s", fields[2])
+				}
+				continue
+			}
+			if len(fields) != 3 && len(fields) != 5 {
+				return nil, fail("expected column, row, value [, row, value]")
+			}
+			v, ok := b.Lookup(fields[0])
+			if !ok {
+				v = b.Var(fields[0])
+			}
+			if integer {
+				b.integer[v.index] = true
+			}
+			for k := 1; k+1 < len(fields); k += 2 {
+				val, err := number(fields[k+1])
+				if err != nil {
+					return nil, err
+				}
+				switch row := fields[k]; {
+				case row == objective:
+					objCoeffs[v.index] += val
+				case ignored[row]:
+				case rowData[row] != nil:
+					rowData[row].coeffs[v.index] += val
+				default:
+					return nil, fail("unknown row %s", row)
+				}
+			}
+
+		case "RHS":
+			// the set name is optional when the pairs are complete
+			pairs := fields
+			if len(fields)%2 == 1 {
+				pairs = fields[1:]
+			}
+			for k := 0; k+1 < len(pairs); k += 2 {
+				val, err := number(pairs[k+1])
+				if err != nil {
+					return nil, err
+				}
+				switch row := pairs[k]; {
+				case row == objective:
+					objConst = -val
+				case ignored[row]:
+				case rowData[row] != nil:
+					rowData[row].rhs = val
+				default:
+					return nil, fail("unknown row %s", row)
+				}
+			}
+
+		case "BOUNDS":
+			if len(fields) < 3 {
+				return nil, fail("expected bound type, set and column")
+			}
+			kind := strings.ToUpper(fields[0])
+			v, ok := b.Lookup(fields[2])
+			if !ok {
+				v = b.Var(fields[2])
+			}
+			bd := &b.bounds[v.index]
+			val := 0.0
+			switch kind {
+			case "UP", "LO", "FX", "UI", "LI":
+				if len(fields) != 4 {
+					return nil, fail("bound %s needs a value", kind)
+				}
+				var err error
+				if val, err = number(fields[3]); err != nil {
+					return nil, err
+				}
+			}
+			switch kind {
+			case "UP":
+				bd.Upper = val
+			case "LO":
+				bd.Lower = val
+			case "UI":
+				bd.Upper = val
+				b.integer[v.index] = true
+			case "LI":
+				bd.Lower = val
+				b.integer[v.index] = true
+			case "BV":
+				bd.Lower, bd.Upper = 0, 1
+				b.integer[v.index] = true
+			case "FX":
+				bd.Lower, bd.Upper = val, val
+			case "FR":
+				bd.Lower, bd.Upper = math.Inf(-1), math.Inf(1)
+			case "MI":
+				bd.Lower = math.Inf(-1)
+			case "PL":
+				bd.Upper = math.Inf(1)
+			default:
+				return nil, fail("unsupported bound type %s", kind)
+			}
+
+		default:
+			return nil, fail("data outside of a section")
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("mps: missing ENDATA")
+}
+
+func mpsSense(b *Builder, s string) error {
+	switch strings.ToUpper(s) {
+	case "MAX", "MAXIMIZE":
+		b.maximize = true
+	case "MIN", "MINIMIZE":
+		b.maximize = false
+	default:
+		return fmt.Errorf("unknown objective sense %s", s)
+	}
+	return nil
+}
+
+func finishMPS(b *Builder, objective string, objCoeffs map[int]float64, objConst float64, rows []string, rowData map[string]*mpsRow) (*Builder, error) {
+	if objective == "" {
+		return nil, fmt.Errorf("mps: no objective row")
+	}
+
+	expr := func(coeffs map[int]float64) Expr {
+		e := Expr{}
+		for _, v := range b.vars {
+			if c, ok := coeffs[v.index]; ok {
+				e = e.AddTerm(c, v)
+			}
+		}
+		return e
+	}
+
+	obj := expr(objCoeffs)
+	obj.Const = objConst
+	b.objective = obj
+	for _, name := range rows {
+		r := rowData[name]
+		b.Constrain(name, expr(r.coeffs), r.sense, r.rhs)
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b, nil
+}
+
+// mpsName rejects names that do not survive splitting MPS lines into
+// fields, and MARKER, which the COLUMNS section reserves.
+func mpsName(name string) error {
+	if f := strings.Fields(name); len(f) != 1 || f[0] != name || strings.Trim(name, "'") == "MARKER" {
+		return fmt.Errorf("mps: name %q cannot be written in MPS format", name)
+	}
+	return nil
+}
+
+// WriteMPS writes the model in free MPS format; the objective row takes
+// the builder's ObjectiveName, obj by default. Names with spaces are an
+// error.
+func WriteMPS(w io.Writer, b *Builder) error {
+	if b.err != nil {
+		return b.err
+	}
+	if err := b.checkNames(mpsName); err != nil {
+		return err
+	}
+	if b.Name != "" {
+		if err := mpsName(b.Name); err != nil {
+			return err
+		}
+	}
+	obj := b.objectiveRow()
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, strings.TrimSpace("NAME "+b.Name))
+	if b.maximize {
+		fmt.Fprintln(bw, "OBJSENSE\n    MAX")
+	}
+
+	fmt.Fprintln(bw, "ROWS")
+	fmt.Fprintf(bw, " N  %s\n", obj)
+	for _, c := range b.constraints {
+		kind := map[Sense]string{LessEqual: "L", GreaterEqual: "G", Equal: "E"}[c.sense]
+		fmt.Fprintf(bw, " %s  %s\n", kind, c.name)
+	}
+
+	fmt.Fprintln(bw, "COLUMNS")
+	objRow := b.coefficients(b.objective)
+	rows := make([][]float64, len(b.constraints))
+	for i, c := range b.constraints {
+		rows[i] = b.coefficients(c.lhs)
+	}
+	marker := false
+	for j, v := range b.vars {
+		if b.integer[j] != marker {
+			marker = b.integer[j]
+			kind := "'INTEND'"
+			if marker {
+				kind = "'INTORG'"
+			}
+			fmt.Fprintf(bw, "    MARKER  'MARKER'  %s\n", kind)
+		}
+		// the objective entry declares the column even when it is zero
+		fmt.Fprintf(bw, "    %s  %s  %s\n", v.name, obj, formatNumber(objRow[j]))
+		for i, c := range b.constraints {
+			if rows[i][j] != 0 {
+				fmt.Fprintf(bw, "    %s  %s  %s\n", v.name, c.name, formatNumber(rows[i][j]))
+			}
+		}
+	}
+	if marker {
+		fmt.Fprintln(bw, "    MARKER  'MARKER'  'INTEND'")
+	}
+
+	fmt.Fprintln(bw, "RHS")
+	if b.objective.Const != 0 {
+		fmt.Fprintf(bw, "    RHS  %s  %s\n", obj, formatNumber(-b.objective.Const))
+	}
+	for _, c := range b.constraints {
+		if rhs := c.rhs - c.lhs.Const; rhs != 0 {
+			fmt.Fprintf(bw, "    RHS  %s  %s\n", c.name, formatNumber(rhs))
+		}
+	}
+
+	fmt.Fprintln(bw, "BOUNDS")
+	for j, v := range b.vars {
+		bd := b.bounds[j]
+		lower, upper := !math.IsInf(bd.Lower, -1), !math.IsInf(bd.Upper, 1)
+		switch {
+		case !lower && !upper:
+			fmt.Fprintf(bw, " FR BND  %s\n", v.name)
+		case lower && upper && bd.Lower == bd.Upper:
+			fmt.Fprintf(bw, " FX BND  %s  %s\n", v.name, formatNumber(bd.Lower))
+		default:
+			if !lower {
+				fmt.Fprintf(bw, " MI BND  %s\n", v.name)
+			} else if bd.Lower != 0 {
+				fmt.Fprintf(bw, " LO BND  %s  %s\n", v.name, formatNumber(bd.Lower))
+			}
+			if upper {
+				fmt.Fprintf(bw, " UP BND  %s  %s\n", v.name, formatNumber(bd.Upper))
+			}
+		}
+	}
+	fmt.Fprintln(bw, "ENDATA")
+	return bw.Flush()
+}
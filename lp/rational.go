@@ -0,0 +1,231 @@
+package lp
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// ratTableau is a dense simplex tableau over rationals. Columns are the
+// original variables, one slack per row and the artificial variables, then
+// the right-hand side.
+type ratTableau struct {
+	rows       [][]*big.Rat
+	basis      []int
+	artificial int // first artificial column
+}
+
+// SolveLPRat solves the same problem as SolveLP, Aub * x <= bub with x >= 0,
+// exactly. It runs a two-phase simplex with Bland's rule, so it always
+// terminates; rows with a negative right-hand side get artificial
+// variables in phase one. The optimal objective value is returned as well.
+func SolveLPRat(c []*big.Rat, Aub [][]*big.Rat, bub []*big.Rat, maximize bool) ([]*big.Rat, *big.Rat, error) {
+	n, m := len(c), len(Aub)
+	if len(bub) != m {
+		return nil, nil, fmt.Errorf("aub/bub size mismatch")
+	}
+	for i, row := range Aub {
+		if len(row) != n {
+			return nil, nil, fmt.Errorf("aub row %d has %d columns, expected %d", i, len(row), n)
+		}
+	}
+
+	if m == 0 {
+		return solveUnconstrainedRat(c, maximize)
+	}
+
+	t := newRatTableau(Aub, bub)
+	width := len(t.rows[0]) - 1
+
+	// phase one: minimise the sum of the artificial variables
+	if t.artificial < width {
+		cost := make([]*big.Rat, width)
+		for j := range cost {
+			cost[j] = new(big.Rat)
+			if j >= t.artificial {
+				cost[j].SetInt64(1)
+			}
+		}
+		if err := t.optimize(cost, width); err != nil {
+			return nil, nil, err
+		}
+		if t.objective(cost).Sign() > 0 {
+			return nil, nil, fmt.Errorf("%w: exact simplex found no feasible point", ErrInfeasible)
+		}
+		t.dropArtificial()
+	}
+
+	// phase two: the artificial variables may no longer enter
+	cost := make([]*big.Rat, width)
+	for j := range cost {
+		cost[j] = new(big.Rat)
+		if j < n {
+			cost[j].Set(c[j])
+			if maximize {
+				cost[j].Neg(cost[j])
+			}
+		}
+	}
+	if err := t.optimize(cost, t.artificial); err != nil {
+		return nil, nil, err
+	}
+
+	x := make([]*big.Rat, n)
+	for j := range x {
+		x[j] = new(big.Rat)
+	}
+	for i, b := range t.basis {
+		if b < n {
+			x[b].Set(t.rows[i][width])
+		}
+	}
+	obj := t.objective(cost)
+	if maximize {
+		obj.Neg(obj)
+	}
+	return x, obj, nil
+}
+
+// solveUnconstrainedRat handles x >= 0 alone: zero is optimal unless some
+// cost improves without limit.
+func solveUnconstrainedRat(c []*big.Rat, maximize bool) ([]*big.Rat, *big.Rat, error) {
+	x := make([]*big.Rat, len(c))
+	for j, v := range c {
+		if maximize && v.Sign() > 0 || !maximize && v.Sign() < 0 {
+			return nil, nil, fmt.Errorf("%w: variable %d has no upper limit", ErrUnbounded, j)
+		}
+		x[j] = new(big.Rat)
+	}
+	return x, new(big.Rat), nil
+}
+
+func newRatTableau(Aub [][]*big.Rat, bub []*big.Rat) *ratTableau {
+	m := len(Aub)
+	n := 0
+	if m > 0 {
+		n = len(Aub[0])
+	}
+
+	negative := 0
+	for _, b := range bub {
+		if b.Sign() < 0 {
+			negative++
+		}
+	}
+
+	width := n + m + negative
+	t := &ratTableau{rows: make([][]*big.Rat, m), basis: make([]int, m), artificial: n + m}
+	next := n + m
+	for i := range Aub {
+		row := make([]*big.Rat, width+1)
+		for j := range row {
+			row[j] = new(big.Rat)
+		}
+		for j, v := range Aub[i] {
+			row[j].Set(v)
+		}
+		row[n+i].SetInt64(1)
+		row[width].Set(bub[i])
+		t.basis[i] = n + i
+
+		if bub[i].Sign() < 0 {
+			for j := range row[:width+1] {
+				row[j].Neg(row[j])
+			}
+			row[next].SetInt64(1)
+			t.basis[i] = next
+			next++
+		}
+		t.rows[i] = row
+	}
+	return t
+}
+
+func (t *ratTableau) objective(cost []*big.Rat) *big.Rat {
+	rhs := len(t.rows[0]) - 1
+	res := new(big.Rat)
+	tmp := new(big.Rat)
+	for i, b := range t.basis {
+		res.Add(res, tmp.Mul(cost[b], t.rows[i][rhs]))
+	}
+	return res
+}
+
+// optimize minimises cost letting only the columns below limit enter.
+func (t *ratTableau) optimize(cost []*big.Rat, limit int) error {
+	if len(t.rows) == 0 {
+		return nil
+	}
+	rhs := len(t.rows[0]) - 1
+	reduced := new(big.Rat)
+	tmp := new(big.Rat)
+	for {
+		// Bland's rule: the lowest improving column enters
+		entering := -1
+		for j := 0; j < limit && entering < 0; j++ {
+			reduced.Set(cost[j])
+			for i, b := range t.basis {
+				reduced.Sub(reduced, tmp.Mul(cost[b], t.rows[i][j]))
+			}
+			if reduced.Sign() < 0 {
+				entering = j
+			}
+		}
+		if entering < 0 {
+			return nil
+		}
+
+		// and the lowest basic variable among the minimum ratios leaves
+		leaving := -1
+		var best *big.Rat
+		for i, row := range t.rows {
+			if row[entering].Sign() <= 0 {
+				continue
+			}
+			ratio := new(big.Rat).Quo(row[rhs], row[entering])
+			if leaving < 0 || ratio.Cmp(best) < 0 || ratio.Cmp(best) == 0 && t.basis[i] < t.basis[leaving] {
+				leaving, best = i, ratio
+			}
+		}
+		if leaving < 0 {
+			return fmt.Errorf("%w: exact simplex found an unbounded ray", ErrUnbounded)
+		}
+		t.pivot(leaving, entering)
+	}
+}
+
+func (t *ratTableau) pivot(r, col int) {
+	pr := t.rows[r]
+	p := new(big.Rat).Set(pr[col])
+	for j := range pr {
+		pr[j].Quo(pr[j], p)
+	}
+
+	tmp := new(big.Rat)
+	for i, row := range t.rows {
+		if i == r || row[col].Sign() == 0 {
+			continue
+		}
+		f := new(big.Rat).Set(row[col])
+		for j := range row {
+			row[j].Sub(row[j], tmp.Mul(f, pr[j]))
+		}
+	}
+	t.basis[r] = col
+}
+
+// dropArtificial pivots the artificial variables left in the basis at zero
+// level out of it. Rows with no other nonzero entry are redundant and keep
+// their artificial variable, which can never change again.
+func (t *ratTableau) dropArtificial() {
+	for i, b := range t.basis {
+		if b < t.artificial {
+			continue
+		}
+		for j := 0; j < t.artificial; j++ {
+			if t.rows[i][j].Sign() != 0 {
+				t.pivot(i, j)
+				break
+			}
+		}
+	}
+}
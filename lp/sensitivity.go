@@ -0,0 +1,152 @@
+package lp
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+const basisTol = 1e-7
+
+// sensitivity fills duals, reduced costs and RHS ranges of an optimal
+// solution. Every constraint gets a slack s_i = RHS_i - Coeffs_i·x, bounded
+// by its sense, so that the rows read [A I](x, s) = b. Variables strictly
+// inside their bounds are basic; in a degenerate solution the basis is
+// completed with slacks first and structural variables next, and the report
+// then belongs to that particular basis.
+func (m *Model) sensitivity(res *Result) error {
+	n, k := len(m.Objective), len(m.Constraints)
+	res.Duals = make([]float64, k)
+	res.RHSRanges = make([]Range, k)
+	res.ReducedCosts = make([]float64, n)
+	copy(res.ReducedCosts, m.Objective)
+	if k == 0 {
+		return nil
+	}
+
+	column := func(j int) []float64 {
+		col := make([]float64, k)
+		if j < n {
+			for i, con := range m.Constraints {
+				col[i] = con.Coeffs[j]
+			}
+		} else {
+			col[j-n] = 1
+		}
+		return col
+	}
+	value := func(j int) float64 {
+		if j < n {
+			return res.X[j]
+		}
+		return res.Slack[j-n]
+	}
+	bound := func(j int) Bound {
+		if j < n {
+			return m.bound(j)
+		}
+		switch m.Constraints[j-n].Sense {
+		case LessEqual:
+			return Bound{Lower: 0, Upper: math.Inf(1)}
+		case GreaterEqual:
+			return Bound{Lower: math.Inf(-1), Upper: 0}
+		default:
+			return Bound{Lower: 0, Upper: 0}
+		}
+	}
+	atBound := func(j int) bool {
+		b, v := bound(j), value(j)
+		return math.Abs(v-b.Lower) <= basisTol || math.Abs(v-b.Upper) <= basisTol
+	}
+
+	basis := []int{}
+	cols := [][]float64{}
+	try := func(j int) {
+		if len(basis) == k {
+			return
+		}
+		if candidate := append(cols, column(j)); independent(candidate) {
+			basis, cols = append(basis, j), candidate
+		}
+	}
+	for j := 0; j < n+k; j++ {
+		if !atBound(j) {
+			try(j)
+		}
+	}
+	for j := n; j < n+k; j++ {
+		try(j)
+	}
+	for j := 0; j < n; j++ {
+		try(j)
+	}
+
+	B := mat.NewDense(k, k, nil)
+	cb := mat.NewVecDense(k, nil)
+	for r, j := range basis {
+		for i, v := range cols[r] {
+			B.Set(i, r, v)
+		}
+		if j < n {
+			cb.SetVec(r, m.Objective[j])
+		}
+	}
+
+	// y solves Bᵀy = c_B; it is the derivative of the objective by b
+	var y mat.VecDense
+	if err := y.SolveVec(B.T(), cb); err != nil {
+		return fmt.Errorf("lp: sensitivity: basis matrix is singular: %v", err)
+	}
+	for i := range res.Duals {
+		res.Duals[i] = y.AtVec(i)
+	}
+	for j := 0; j < n; j++ {
+		res.ReducedCosts[j] = m.Objective[j] - dot(column(j), res.Duals)
+	}
+
+	for i, con := range m.Constraints {
+		// x_B moves along B⁻¹e_i as b_i changes
+		var d mat.VecDense
+		e := mat.NewVecDense(k, nil)
+		e.SetVec(i, 1)
+		if err := d.SolveVec(B, e); err != nil {
+			return fmt.Errorf("lp: sensitivity: ranging constraint %d: %v", i, err)
+		}
+
+		low, high := math.Inf(-1), math.Inf(1)
+		for r, j := range basis {
+			dr := d.AtVec(r)
+			if math.Abs(dr) <= basisTol {
+				continue
+			}
+			b, v := bound(j), value(j)
+			lo, hi := (b.Lower-v)/dr, (b.Upper-v)/dr
+			if dr < 0 {
+				lo, hi = hi, lo
+			}
+			low, high = math.Max(low, lo), math.Min(high, hi)
+		}
+		res.RHSRanges[i] = Range{Lower: con.RHS + low, Upper: con.RHS + high}
+	}
+	return nil
+}
+
+// independent reports whether the columns are linearly independent.
+func independent(cols [][]float64) bool {
+	k := len(cols[0])
+	if len(cols) > k {
+		return false
+	}
+	a := mat.NewDense(k, len(cols), nil)
+	for c, col := range cols {
+		for i, v := range col {
+			a.Set(i, c, v)
+		}
+	}
+	var svd mat.SVD
+	if !svd.Factorize(a, mat.SVDNone) {
+		return false
+	}
+	return svd.Rank(basisTol) == len(cols)
+}
@@ -0,0 +1,18 @@
+* cheapest diet meeting calorie and protein needs, at most 3 units of bread
+NAME          diet
+ROWS
+ N  cost
+ G  calories
+ G  protein
+COLUMNS
+    bread  cost  2  calories  300
+    bread  protein  8
+    milk  cost  3.5  calories  160
+    milk  protein  9
+    cheese  cost  8  calories  420
+    cheese  protein  25
+RHS
+    RHS  calories  2000  protein  60
+BOUNDS
+ UP BND  bread  3
+ENDATA
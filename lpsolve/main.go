@@ -0,0 +1,116 @@
+package main
+
+import (
+	"decision-theory/lp"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+func printSolution(b *lp.Builder, sol lp.Solution) {
+	fmt.Printf("Objective: %.6g\n", sol.Objective)
+
+	fmt.Println("\nVariables:")
+	for _, v := range b.Vars() {
+		name := v.Name()
+		fmt.Printf("  %-12s %12.6g  reduced cost %.6g\n", name, sol.Values[name], sol.ReducedCosts[name])
+	}
+
+	names := b.ConstraintNames()
+	if len(names) == 0 {
+		return
+	}
+	fmt.Println("\nConstraints:")
+	for _, name := range names {
+		r := sol.RHSRanges[name]
+		fmt.Printf("  %-12s rhs-lhs %12.6g  dual %12.6g  rhs range [%.6g, %.6g]\n",
+			name, sol.Slack[name], sol.Duals[name], r.Lower, r.Upper)
+	}
+}
+
+func printMILPSolution(b *lp.Builder, sol lp.MILPSolution) {
+	if sol.Result.Optimal {
+		fmt.Printf("Objective: %.6g (optimal, %d nodes)\n", sol.Objective, sol.Result.Nodes)
+	} else {
+		fmt.Printf("Objective: %.6g (node limit after %d nodes, bound %.6g, gap %.2f%%)\n",
+			sol.Objective, sol.Result.Nodes, sol.Bound, 100*sol.Result.Gap())
+	}
+
+	fmt.Println("\nVariables:")
+	for _, v := range b.Vars() {
+		fmt.Printf("  %-12s %12.6g\n", v.Name(), sol.Values[v.Name()])
+	}
+}
+
+func main() {
+	file := flag.String("f", "", "model file (.lp for CPLEX LP, .mps for free MPS)")
+	out := flag.String("o", "", "file to write the model to, format by extension")
+	verbose := flag.Bool("v", false, "print the model before solving")
+	relax := flag.Bool("relax", false, "solve the LP relaxation of an integer model")
+	dfs := flag.Bool("dfs", false, "depth-first instead of best-bound node selection")
+	nodes := flag.Int("nodes", 0, "branch-and-bound node limit, 0 for the default of 10000, negative for none")
+	trace := flag.Bool("trace", false, "print every improving integer solution")
+
+	flag.Parse()
+
+	if *file == "" && flag.NArg() > 0 {
+		*file = flag.Arg(0)
+	}
+	if *file == "" {
+		fmt.Fprintln(os.Stderr, "usage: lpsolve [-v] [-o out.lp|out.mps] -f model.lp|model.mps")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+
+	b, err := lp.ReadFile(*file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *verbose {
+		fmt.Print(b)
+		fmt.Println()
+	}
+	if *out != "" {
+		if err := lp.WriteFile(*out, b); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	if b.HasIntegers() && !*relax {
+		opts := lp.MILPOptions{NodeLimit: *nodes}
+		if *dfs {
+			opts.Selection = lp.DepthFirst
+		}
+		if *trace {
+			opts.OnIncumbent = func(inc lp.Incumbent) {
+				fmt.Printf("node %d: incumbent %.6g, bound %.6g\n", inc.Node, inc.Objective, inc.Bound)
+			}
+		}
+		sol, err := b.SolveMILP(opts)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		printMILPSolution(b, sol)
+		return
+	}
+
+	b.Sensitivity = true
+	sol, err := b.Solve()
+	switch {
+	case errors.Is(err, lp.ErrInfeasible):
+		fmt.Println("Model is infeasible:", err)
+		os.Exit(1)
+	case errors.Is(err, lp.ErrUnbounded):
+		fmt.Println("Model is unbounded:", err)
+		os.Exit(1)
+	case err != nil:
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	printSolution(b, sol)
+}
@@ -0,0 +1,188 @@
+package nash
+
+import (
+	"fmt"
+	"math"
+)
+
+// maxPivots guards Lemke–Howson against cycling in degenerate games.
+const maxPivots = 10000
+
+// tableau is a system of equations with an explicit basis. Columns are the
+// labels 0..m+n-1 followed by the right-hand side; labels 0..m-1 belong to
+// the row player's strategies and m..m+n-1 to the column player's.
+type tableau struct {
+	rows  [][]float64
+	basis []int
+}
+
+func (t *tableau) hasBasic(label int) bool {
+	for _, b := range t.basis {
+		if b == label {
+			return true
+		}
+	}
+	return false
+}
+
+// pivot brings label into the basis by the minimum ratio test and returns
+// the label that leaves.
+func (t *tableau) pivot(label int) (int, error) {
+	rhs := len(t.rows[0]) - 1
+	best, ratio := -1, math.Inf(1)
+	for r, row := range t.rows {
+		if row[label] <= tol {
+			continue
+		}
+		if q := row[rhs] / row[label]; q < ratio-tol {
+			best, ratio = r, q
+		}
+	}
+	if best < 0 {
+		return 0, fmt.Errorf("label %d cannot enter the basis", label)
+	}
+
+	pr := t.rows[best]
+	p := pr[label]
+	for c := range pr {
+		pr[c] /= p
+	}
+	for r, row := range t.rows {
+		if r == best || row[label] == 0 {
+			continue
+		}
+		f := row[label]
+		for c := range row {
+			row[c] -= f * pr[c]
+		}
+	}
+
+	leaving := t.basis[best]
+	t.basis[best] = label
+	return leaving, nil
+}
+
+// strategy reads the normalised values of labels from..from+size-1.
+func (t *tableau) strategy(from, size int) []float64 {
+	rhs := len(t.rows[0]) - 1
+	x := make([]float64, size)
+	total := 0.0
+	for r, b := range t.basis {
+		if b >= from && b < from+size {
+			x[b-from] = math.Max(t.rows[r][rhs], 0)
+			total += x[b-from]
+		}
+	}
+	for i := range x {
+		x[i] /= total
+	}
+	return x
+}
+
+// LemkeHowson follows the path of almost complementary vertices of the best
+// response polytopes that starts by dropping label (a row strategy for
+// label < m, the column strategy label-m otherwise) and returns the
+// equilibrium at its end. Payoffs are shifted to be positive first, which
+// does not change the equilibria.
+func LemkeHowson(a, b [][]float64, label int) (Equilibrium, error) {
+	if err := validate(a, b); err != nil {
+		return Equilibrium{}, err
+	}
+	m, n := len(a), len(a[0])
+	if label < 0 || label >= m+n {
+		return Equilibrium{}, fmt.Errorf("label %d out of range [0, %d)", label, m+n)
+	}
+
+	pa, pb := positive(a), positive(b)
+
+	// col: A y + s = 1 with the slacks s labelled by rows, y by columns
+	col := &tableau{rows: make([][]float64, m), basis: make([]int, m)}
+	for i := 0; i < m; i++ {
+		row := make([]float64, m+n+1)
+		row[i] = 1
+		for j := 0; j < n; j++ {
+			row[m+j] = pa[i][j]
+		}
+		row[m+n] = 1
+		col.rows[i], col.basis[i] = row, i
+	}
+
+	// row: Bᵀ x + r = 1 with x labelled by rows, the slacks r by columns
+	row := &tableau{rows: make([][]float64, n), basis: make([]int, n)}
+	for j := 0; j < n; j++ {
+		r := make([]float64, m+n+1)
+		for i := 0; i < m; i++ {
+			r[i] = pb[i][j]
+		}
+		r[m+j] = 1
+		r[m+n] = 1
+		row.rows[j], row.basis[j] = r, m+j
+	}
+
+	current, other := row, col
+	if label >= m {
+		current, other = col, row
+	}
+
+	entering := label
+	for step := 0; ; step++ {
+		if step == maxPivots {
+			return Equilibrium{}, fmt.Errorf("no equilibrium after %d pivots, the game may be degenerate", maxPivots)
+		}
+		leaving, err := current.pivot(entering)
+		if err != nil {
+			return Equilibrium{}, err
+		}
+		if leaving == label {
+			break
+		}
+		entering = leaving
+		current, other = other, current
+	}
+
+	x := row.strategy(0, m)
+	y := col.strategy(m, n)
+	return newEquilibrium(a, b, x, y), nil
+}
+
+// LemkeHowsonAll runs LemkeHowson from every label and collects the distinct
+// equilibria. This finds at least one equilibrium but in general not all.
+func LemkeHowsonAll(a, b [][]float64) (Result, error) {
+	if err := validate(a, b); err != nil {
+		return Result{}, err
+	}
+
+	res := Result{Equilibria: []Equilibrium{}}
+	var lastErr error
+	for label := 0; label < len(a)+len(a[0]); label++ {
+		e, err := LemkeHowson(a, b, label)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		res.add(e)
+	}
+	if len(res.Equilibria) == 0 {
+		return res, lastErr
+	}
+	res.Degenerate = degenerate(a, b, res.Equilibria)
+	return res, nil
+}
+
+// positive shifts a so that its smallest entry is 1.
+func positive(a [][]float64) [][]float64 {
+	low := math.Inf(1)
+	for _, row := range a {
+		for _, v := range row {
+			low = math.Min(low, v)
+		}
+	}
+	res := make([][]float64, len(a))
+	for i, row := range a {
+		res[i] = make([]float64, len(row))
+		for j, v := range row {
+			res[i][j] = v - low + 1
+		}
+	}
+	return res
+}
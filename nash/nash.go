@@ -0,0 +1,318 @@
+// Package nash finds Nash equilibria of bimatrix games.
+package nash
+
+import (
+	"decision-theory/games"
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+const tol = 1e-9
+
+// supportLimit bounds the game size solved by support enumeration, which
+// checks C(m+n, m) pairs of supports; larger games use Lemke–Howson.
+const supportLimit = 10
+
+// Equilibrium is a pair of mixed strategies that are best responses to each
+// other, with the expected payoff of each player.
+type Equilibrium struct {
+	Row       []float64
+	Col       []float64
+	RowPayoff float64
+	ColPayoff float64
+}
+
+// Supports returns the strategies played with positive probability.
+func (e Equilibrium) Supports() ([]int, []int) {
+	return support(e.Row), support(e.Col)
+}
+
+// Pure reports whether both players play a single strategy.
+func (e Equilibrium) Pure() bool {
+	rows, cols := e.Supports()
+	return len(rows) == 1 && len(cols) == 1
+}
+
+func (e Equilibrium) String() string {
+	return fmt.Sprintf("x=%s y=%s payoffs (%.4f, %.4f)", format(e.Row), format(e.Col), e.RowPayoff, e.ColPayoff)
+}
+
+// Result lists the equilibria found for a game.
+type Result struct {
+	Equilibria []Equilibrium
+	// Degenerate is set when some strategy has more pure best responses than
+	// the size of its support. Such games may have infinitely many
+	// equilibria, and only some of them are listed.
+	Degenerate bool
+}
+
+// Solve enumerates supports for games up to supportLimit strategies per
+// player and runs Lemke–Howson from every starting label otherwise.
+func Solve(g games.Bimatrix) (Result, error) {
+	a, b := games.ToFloat(g.A), games.ToFloat(g.B)
+	if g.Rows() <= supportLimit && g.Cols() <= supportLimit {
+		return SupportEnumeration(a, b)
+	}
+	return LemkeHowsonAll(a, b)
+}
+
+// validate checks both payoff matrices and that their shapes agree.
+func validate(a, b [][]float64) error {
+	if err := games.ValidatePayoff(a); err != nil {
+		return err
+	}
+	if err := games.ValidatePayoff(b); err != nil {
+		return err
+	}
+	if len(b) != len(a) || len(b[0]) != len(a[0]) {
+		return fmt.Errorf("payoff matrices are %dx%d and %dx%d", len(a), len(a[0]), len(b), len(b[0]))
+	}
+	return nil
+}
+
+// PureEquilibria lists the cells where both strategies are best responses.
+func PureEquilibria(a, b [][]float64) ([]Equilibrium, error) {
+	if err := validate(a, b); err != nil {
+		return nil, err
+	}
+
+	m, n := len(a), len(a[0])
+	res := []Equilibrium{}
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			best := true
+			for k := 0; k < m && best; k++ {
+				best = a[k][j] <= a[i][j]+tol
+			}
+			for l := 0; l < n && best; l++ {
+				best = b[i][l] <= b[i][j]+tol
+			}
+			if best {
+				res = append(res, newEquilibrium(a, b, unit(m, i), unit(n, j)))
+			}
+		}
+	}
+	return res, nil
+}
+
+// SupportEnumeration tries every pair of supports of equal size k: the
+// opponent's strategy on the support must make all k strategies equally good
+//
+//	Σ_j a_ij y_j = v for i in I, Σ y_j = 1
+//
+// and no strategy outside the support may be better. In nondegenerate games
+// this finds every equilibrium.
+func SupportEnumeration(a, b [][]float64) (Result, error) {
+	if err := validate(a, b); err != nil {
+		return Result{}, err
+	}
+
+	m, n := len(a), len(a[0])
+	bt := transpose(b)
+	res := Result{Equilibria: []Equilibrium{}}
+	for k := 1; k <= min(m, n); k++ {
+		for _, rows := range subsets(m, k) {
+			for _, cols := range subsets(n, k) {
+				y, ok := indifferent(a, rows, cols)
+				if !ok {
+					continue
+				}
+				x, ok := indifferent(bt, cols, rows)
+				if !ok {
+					continue
+				}
+				if !bestResponse(a, y, rows) || !bestResponse(bt, x, cols) {
+					continue
+				}
+				res.add(newEquilibrium(a, b, x, y))
+			}
+		}
+	}
+	res.Degenerate = degenerate(a, b, res.Equilibria)
+	return res, nil
+}
+
+// indifferent solves for the opponent's mixed strategy on cols that makes
+// every strategy in rows give the same payoff.
+func indifferent(a [][]float64, rows, cols []int) ([]float64, bool) {
+	k := len(rows)
+	sys := mat.NewDense(k+1, k+1, nil)
+	rhs := mat.NewVecDense(k+1, nil)
+	for r, i := range rows {
+		for c, j := range cols {
+			sys.Set(r, c, a[i][j])
+		}
+		sys.Set(r, k, -1)
+	}
+	for c := range cols {
+		sys.Set(k, c, 1)
+	}
+	rhs.SetVec(k, 1)
+
+	var sol mat.VecDense
+	if err := sol.SolveVec(sys, rhs); err != nil {
+		return nil, false
+	}
+
+	y := make([]float64, len(a[0]))
+	for c, j := range cols {
+		p := sol.AtVec(c)
+		if p < -tol || math.IsNaN(p) {
+			return nil, false
+		}
+		y[j] = math.Max(p, 0)
+	}
+	return y, true
+}
+
+// bestResponse checks that the strategies in rows earn the most against y.
+func bestResponse(a [][]float64, y []float64, rows []int) bool {
+	pay := apply(a, y)
+	best := math.Inf(-1)
+	for _, v := range pay {
+		best = math.Max(best, v)
+	}
+	for _, i := range rows {
+		if pay[i] < best-1e-7 {
+			return false
+		}
+	}
+	return true
+}
+
+// degenerate checks pure strategies and the equilibria found for more best
+// responses than the size of the support.
+func degenerate(a, b [][]float64, eqs []Equilibrium) bool {
+	m, n := len(a), len(a[0])
+	bt := transpose(b)
+	for i := 0; i < m; i++ {
+		if len(bestResponses(bt, unit(m, i))) > 1 {
+			return true
+		}
+	}
+	for j := 0; j < n; j++ {
+		if len(bestResponses(a, unit(n, j))) > 1 {
+			return true
+		}
+	}
+	for _, e := range eqs {
+		rows, cols := e.Supports()
+		if len(bestResponses(a, e.Col)) > len(cols) || len(bestResponses(bt, e.Row)) > len(rows) {
+			return true
+		}
+	}
+	return false
+}
+
+func bestResponses(a [][]float64, y []float64) []int {
+	pay := apply(a, y)
+	best := math.Inf(-1)
+	for _, v := range pay {
+		best = math.Max(best, v)
+	}
+	res := []int{}
+	for i, v := range pay {
+		if v >= best-1e-7 {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+func (r *Result) add(e Equilibrium) {
+	for _, f := range r.Equilibria {
+		if near(f.Row, e.Row) && near(f.Col, e.Col) {
+			return
+		}
+	}
+	r.Equilibria = append(r.Equilibria, e)
+}
+
+func newEquilibrium(a, b [][]float64, x, y []float64) Equilibrium {
+	return Equilibrium{Row: x, Col: y, RowPayoff: dot(x, apply(a, y)), ColPayoff: dot(x, apply(b, y))}
+}
+
+func apply(a [][]float64, y []float64) []float64 {
+	res := make([]float64, len(a))
+	for i := range a {
+		res[i] = dot(a[i], y)
+	}
+	return res
+}
+
+func dot(x, y []float64) float64 {
+	s := 0.0
+	for i := range x {
+		s += x[i] * y[i]
+	}
+	return s
+}
+
+func transpose(a [][]float64) [][]float64 {
+	res := make([][]float64, len(a[0]))
+	for j := range res {
+		res[j] = make([]float64, len(a))
+		for i := range a {
+			res[j][i] = a[i][j]
+		}
+	}
+	return res
+}
+
+func unit(n, i int) []float64 {
+	v := make([]float64, n)
+	v[i] = 1
+	return v
+}
+
+func support(x []float64) []int {
+	res := []int{}
+	for i, v := range x {
+		if v > tol {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+func near(x, y []float64) bool {
+	for i := range x {
+		if math.Abs(x[i]-y[i]) > 1e-6 {
+			return false
+		}
+	}
+	return true
+}
+
+// subsets lists the k-element subsets of {0..n-1} in lexicographic order.
+func subsets(n, k int) [][]int {
+	res := [][]int{}
+	current := make([]int, 0, k)
+	var walk func(from int)
+	walk = func(from int) {
+		if len(current) == k {
+			res = append(res, append([]int{}, current...))
+			return
+		}
+		for i := from; i <= n-(k-len(current)); i++ {
+			current = append(current, i)
+			walk(i + 1)
+			current = current[:len(current)-1]
+		}
+	}
+	walk(0)
+	return res
+}
+
+func format(x []float64) string {
+	s := "("
+	for i, v := range x {
+		if i > 0 {
+			s += ", "
+		}
+		s += fmt.Sprintf("%.4f", v)
+	}
+	return s + ")"
+}
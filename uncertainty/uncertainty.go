@@ -0,0 +1,260 @@
+// Package uncertainty applies the classical decision criteria to a payoff
+// table whose rows are decisions and whose columns are states of nature.
+package uncertainty
+
+import (
+	"decision-theory/games"
+	"fmt"
+	"math"
+	"slices"
+)
+
+const tol = 1e-9
+
+type Criterion int
+
+const (
+	Wald Criterion = iota
+	Maximax
+	Savage
+	Hurwicz
+	Laplace
+	Bayes
+)
+
+var criterionNames = []string{
+	"Wald (maximin)",
+	"maximax",
+	"Savage (minimax regret)",
+	"Hurwicz",
+	"Laplace",
+	"Bayes",
+}
+
+func (c Criterion) String() string {
+	if c < 0 || int(c) >= len(criterionNames) {
+		return "unknown"
+	}
+	return criterionNames[c]
+}
+
+// Decision is the score of every row under one criterion and the rows
+// attaining the best score. Savage minimises its score, every other
+// criterion maximises it.
+type Decision struct {
+	Criterion Criterion
+	Scores    []float64
+	Best      []int
+	Value     float64
+}
+
+func decide(c Criterion, scores []float64, minimise bool) Decision {
+	value := scores[0]
+	for _, s := range scores {
+		if minimise {
+			value = math.Min(value, s)
+		} else {
+			value = math.Max(value, s)
+		}
+	}
+
+	d := Decision{Criterion: c, Scores: scores, Best: []int{}, Value: value}
+	for i, s := range scores {
+		if math.Abs(s-value) <= tol {
+			d.Best = append(d.Best, i)
+		}
+	}
+	return d
+}
+
+func rowScores(payoff [][]float64, score func(row []float64) float64) []float64 {
+	res := make([]float64, len(payoff))
+	for i, row := range payoff {
+		res[i] = score(row)
+	}
+	return res
+}
+
+func WaldCriterion(payoff [][]float64) (Decision, error) {
+	if err := games.ValidatePayoff(payoff); err != nil {
+		return Decision{}, err
+	}
+	return decide(Wald, rowScores(payoff, slices.Min[[]float64]), false), nil
+}
+
+func MaximaxCriterion(payoff [][]float64) (Decision, error) {
+	if err := games.ValidatePayoff(payoff); err != nil {
+		return Decision{}, err
+	}
+	return decide(Maximax, rowScores(payoff, slices.Max[[]float64]), false), nil
+}
+
+// Regret returns r_ij = max_k a_kj - a_ij, what is lost by choosing row i
+// when the state j occurs.
+func Regret(payoff [][]float64) ([][]float64, error) {
+	if err := games.ValidatePayoff(payoff); err != nil {
+		return nil, err
+	}
+
+	res := make([][]float64, len(payoff))
+	for i := range res {
+		res[i] = make([]float64, len(payoff[0]))
+	}
+	for j := range payoff[0] {
+		best := math.Inf(-1)
+		for i := range payoff {
+			best = math.Max(best, payoff[i][j])
+		}
+		for i := range payoff {
+			res[i][j] = best - payoff[i][j]
+		}
+	}
+	return res, nil
+}
+
+func SavageCriterion(payoff [][]float64) (Decision, error) {
+	regret, err := Regret(payoff)
+	if err != nil {
+		return Decision{}, err
+	}
+	return decide(Savage, rowScores(regret, slices.Max[[]float64]), true), nil
+}
+
+// HurwiczCriterion scores a row by λ·min + (1-λ)·max, where λ in [0, 1] is
+// the pessimism coefficient: λ = 1 is Wald, λ = 0 is maximax.
+func HurwiczCriterion(payoff [][]float64, pessimism float64) (Decision, error) {
+	if err := games.ValidatePayoff(payoff); err != nil {
+		return Decision{}, err
+	}
+	if pessimism < 0 || pessimism > 1 {
+		return Decision{}, fmt.Errorf("pessimism coefficient must be in [0, 1], got %v", pessimism)
+	}
+	return decide(Hurwicz, rowScores(payoff, func(row []float64) float64 {
+		return pessimism*slices.Min(row) + (1-pessimism)*slices.Max(row)
+	}), false), nil
+}
+
+// LaplaceCriterion treats all states as equally likely.
+func LaplaceCriterion(payoff [][]float64) (Decision, error) {
+	if err := games.ValidatePayoff(payoff); err != nil {
+		return Decision{}, err
+	}
+	probs := make([]float64, len(payoff[0]))
+	for j := range probs {
+		probs[j] = 1 / float64(len(probs))
+	}
+	d, err := BayesCriterion(payoff, probs)
+	d.Criterion = Laplace
+	return d, err
+}
+
+// BayesCriterion maximises the expected payoff under the state probabilities.
+func BayesCriterion(payoff [][]float64, probs []float64) (Decision, error) {
+	if err := games.ValidatePayoff(payoff); err != nil {
+		return Decision{}, err
+	}
+	if len(probs) != len(payoff[0]) {
+		return Decision{}, fmt.Errorf("got %d probabilities for %d states", len(probs), len(payoff[0]))
+	}
+	total := 0.0
+	for j, p := range probs {
+		if p < 0 {
+			return Decision{}, fmt.Errorf("probability of state %d is negative: %v", j, p)
+		}
+		total += p
+	}
+	if math.Abs(total-1) > 1e-6 {
+		return Decision{}, fmt.Errorf("probabilities sum to %v, expected 1", total)
+	}
+
+	return decide(Bayes, rowScores(payoff, func(row []float64) float64 {
+		s := 0.0
+		for j, v := range row {
+			s += probs[j] * v
+		}
+		return s
+	}), false), nil
+}
+
+// Report collects every criterion applied to one payoff table.
+type Report struct {
+	Regret    [][]float64
+	Decisions []Decision
+}
+
+// Analyze applies all criteria; Bayes is skipped when probs is nil.
+func Analyze(payoff [][]float64, pessimism float64, probs []float64) (Report, error) {
+	regret, err := Regret(payoff)
+	if err != nil {
+		return Report{}, err
+	}
+
+	criteria := []func() (Decision, error){
+		func() (Decision, error) { return WaldCriterion(payoff) },
+		func() (Decision, error) { return MaximaxCriterion(payoff) },
+		func() (Decision, error) { return SavageCriterion(payoff) },
+		func() (Decision, error) { return HurwiczCriterion(payoff, pessimism) },
+		func() (Decision, error) { return LaplaceCriterion(payoff) },
+	}
+	if probs != nil {
+		criteria = append(criteria, func() (Decision, error) { return BayesCriterion(payoff, probs) })
+	}
+
+	res := Report{Regret: regret}
+	for _, c := range criteria {
+		d, err := c()
+		if err != nil {
+			return Report{}, err
+		}
+		res.Decisions = append(res.Decisions, d)
+	}
+	return res, nil
+}
+
+// Segment is an interval of pessimism coefficients with the same Hurwicz
+// choice.
+type Segment struct {
+	From float64
+	To   float64
+	Best []int
+}
+
+// HurwiczCurve splits [0, 1] into intervals of the pessimism coefficient
+// over which the Hurwicz choice does not change. Every row's score is linear
+// in λ, so the choice can only change where two score lines cross.
+func HurwiczCurve(payoff [][]float64) ([]Segment, error) {
+	if err := games.ValidatePayoff(payoff); err != nil {
+		return nil, err
+	}
+
+	// score_i(λ) = hi_i + λ(lo_i - hi_i)
+	lo := rowScores(payoff, slices.Min[[]float64])
+	hi := rowScores(payoff, slices.Max[[]float64])
+	points := []float64{0, 1}
+	for a := range payoff {
+		for b := a + 1; b < len(payoff); b++ {
+			slope := (lo[a] - hi[a]) - (lo[b] - hi[b])
+			if math.Abs(slope) <= tol {
+				continue
+			}
+			if l := (hi[b] - hi[a]) / slope; l > 0 && l < 1 {
+				points = append(points, l)
+			}
+		}
+	}
+	slices.Sort(points)
+	points = slices.CompactFunc(points, func(a, b float64) bool {
+		return math.Abs(a-b) <= tol
+	})
+
+	res := []Segment{}
+	for k := 0; k+1 < len(points); k++ {
+		d, _ := HurwiczCriterion(payoff, (points[k]+points[k+1])/2)
+		if n := len(res); n > 0 && slices.Equal(res[n-1].Best, d.Best) {
+			res[n-1].To = points[k+1]
+			continue
+		}
+		res = append(res, Segment{From: points[k], To: points[k+1], Best: d.Best})
+	}
+	return res, nil
+}
@@ -0,0 +1,333 @@
+package zerosum
+
+import (
+	"decision-theory/games"
+	"decision-theory/lp"
+	"fmt"
+	"strings"
+)
+
+const dominanceTol = 1e-9
+
+const (
+	RowPlayer = iota
+	ColPlayer
+)
+
+type DominanceOptions struct {
+	// Weak also removes weakly dominated strategies (never worse, sometimes
+	// better). The result may then depend on the elimination order.
+	Weak bool
+	// Mixed also checks domination by mixtures of the remaining strategies.
+	Mixed bool
+}
+
+type Weight struct {
+	Index int
+	Prob  float64
+}
+
+// Step records one removed strategy. Indices refer to the original game.
+type Step struct {
+	Player int
+	Index  int
+	By     []Weight
+	Strict bool
+}
+
+func (s Step) String() string {
+	name := "row"
+	prefix := "r"
+	if s.Player == ColPlayer {
+		name = "column"
+		prefix = "c"
+	}
+
+	kind := "weakly "
+	if s.Strict {
+		kind = ""
+	}
+
+	parts := make([]string, len(s.By))
+	for k, w := range s.By {
+		if len(s.By) == 1 {
+			parts[k] = fmt.Sprintf("%s%d", prefix, w.Index)
+		} else {
+			parts[k] = fmt.Sprintf("%.4g·%s%d", w.Prob, prefix, w.Index)
+		}
+	}
+	return fmt.Sprintf("%s %d removed: %sdominated by %s", name, s.Index, kind, strings.Join(parts, "+"))
+}
+
+// Reduction is the game left after iterated elimination. Rows and Cols map
+// its strategies back to the original indices.
+type Reduction struct {
+	Payoff [][]float64
+	Rows   []int
+	Cols   []int
+	Steps  []Step
+}
+
+// Expand maps a solution of the reduced game back to the original game;
+// removed strategies get probability zero.
+func (r Reduction) Expand(s Solution, rows, cols int) Solution {
+	x := make([]float64, rows)
+	y := make([]float64, cols)
+	for k, i := range r.Rows {
+		x[i] = s.RowStrategy[k]
+	}
+	for k, j := range r.Cols {
+		y[j] = s.ColStrategy[k]
+	}
+
+	res := Solution{RowStrategy: x, ColStrategy: y, Value: s.Value, Saddle: s.Saddle}
+	for _, p := range s.SaddlePoints {
+		res.SaddlePoints = append(res.SaddlePoints, [2]int{r.Rows[p[0]], r.Cols[p[1]]})
+	}
+	return res
+}
+
+// Reduce removes dominated strategies of both players until none is left.
+func Reduce(payoff [][]float64, opts DominanceOptions) (Reduction, error) {
+	if err := games.ValidatePayoff(payoff); err != nil {
+		return Reduction{}, err
+	}
+
+	red := Reduction{Rows: indices(len(payoff)), Cols: indices(len(payoff[0])), Steps: []Step{}}
+	for changed := true; changed; {
+		changed = false
+
+		view := submatrix(payoff, red.Rows, red.Cols)
+		if k, by, strict, ok := findDominated(view, opts); ok {
+			red.Steps = append(red.Steps, Step{Player: RowPlayer, Index: red.Rows[k], By: remap(by, red.Rows), Strict: strict})
+			red.Rows = append(red.Rows[:k:k], red.Rows[k+1:]...)
+			changed = true
+			continue
+		}
+
+		// the column player minimises, so its dominance is row dominance in -Aᵀ
+		if k, by, strict, ok := findDominated(negTranspose(view), opts); ok {
+			red.Steps = append(red.Steps, Step{Player: ColPlayer, Index: red.Cols[k], By: remap(by, red.Cols), Strict: strict})
+			red.Cols = append(red.Cols[:k:k], red.Cols[k+1:]...)
+			changed = true
+		}
+	}
+
+	red.Payoff = submatrix(payoff, red.Rows, red.Cols)
+	return red, nil
+}
+
+// SolveReduced eliminates dominated strategies, solves the smaller game and
+// maps the strategies back to the original indices.
+func SolveReduced(payoff [][]float64, opts DominanceOptions) (Solution, Reduction, error) {
+	red, err := Reduce(payoff, opts)
+	if err != nil {
+		return Solution{}, red, err
+	}
+
+	sol, err := Solve(red.Payoff)
+	if err != nil {
+		return Solution{}, red, err
+	}
+	return red.Expand(sol, len(payoff), len(payoff[0])), red, nil
+}
+
+func indices(n int) []int {
+	res := make([]int, n)
+	for i := range res {
+		res[i] = i
+	}
+	return res
+}
+
+func submatrix(m [][]float64, rows, cols []int) [][]float64 {
+	res := make([][]float64, len(rows))
+	for a, i := range rows {
+		res[a] = make([]float64, len(cols))
+		for b, j := range cols {
+			res[a][b] = m[i][j]
+		}
+	}
+	return res
+}
+
+func negTranspose(m [][]float64) [][]float64 {
+	res := make([][]float64, len(m[0]))
+	for j := range res {
+		res[j] = make([]float64, len(m))
+		for i := range m {
+			res[j][i] = -m[i][j]
+		}
+	}
+	return res
+}
+
+func remap(by []Weight, idx []int) []Weight {
+	res := make([]Weight, len(by))
+	for k, w := range by {
+		res[k] = Weight{Index: idx[w.Index], Prob: w.Prob}
+	}
+	return res
+}
+
+// findDominated returns the first row (of a maximising player) dominated by
+// another row or, with opts.Mixed, by a mixture of the other rows.
+func findDominated(m [][]float64, opts DominanceOptions) (int, []Weight, bool, bool) {
+	if len(m) < 2 {
+		return 0, nil, false, false
+	}
+
+	for i := range m {
+		for k := range m {
+			if k == i {
+				continue
+			}
+			if strict, ok := pureDominates(m[k], m[i], opts.Weak); ok {
+				return i, []Weight{{Index: k, Prob: 1}}, strict, true
+			}
+		}
+	}
+
+	if !opts.Mixed || len(m) < 3 {
+		return 0, nil, false, false
+	}
+
+	for i := range m {
+		if by, ok := mixedStrictlyDominated(m, i); ok {
+			return i, by, true, true
+		}
+	}
+	if opts.Weak {
+		for i := range m {
+			if by, ok := mixedWeaklyDominated(m, i); ok {
+				return i, by, false, true
+			}
+		}
+	}
+	return 0, nil, false, false
+}
+
+func pureDominates(better, worse []float64, weak bool) (bool, bool) {
+	strict, somewhere := true, false
+	for j := range better {
+		if better[j] < worse[j]-dominanceTol {
+			return false, false
+		}
+		if better[j] > worse[j]+dominanceTol {
+			somewhere = true
+		} else {
+			strict = false
+		}
+	}
+	if strict {
+		return true, true
+	}
+	return false, weak && somewhere
+}
+
+// mixedStrictlyDominated shifts the rows to be strictly positive and solves
+//
+//	min Σq  s.t.  Σ_k q_k a_kj >= a_ij for every column j, q >= 0
+//
+// over the other rows k. Row i is strictly dominated by p = q/Σq exactly
+// when the optimum is below one.
+func mixedStrictlyDominated(m [][]float64, i int) ([]Weight, bool) {
+	positive, _ := makePositive(m)
+	others := othersOf(len(m), i)
+	cols := len(m[0])
+
+	c := make([]float64, len(others))
+	for k := range c {
+		c[k] = 1
+	}
+	Aub := make([][]float64, cols)
+	bub := make([]float64, cols)
+	for j := 0; j < cols; j++ {
+		Aub[j] = make([]float64, len(others))
+		for k, r := range others {
+			Aub[j][k] = -positive[r][j]
+		}
+		bub[j] = -positive[i][j]
+	}
+
+	q, err := lp.SolveLP(c, Aub, bub, false)
+	if err != nil {
+		return nil, false
+	}
+	total := sum(q)
+	if total >= 1-dominanceTol {
+		return nil, false
+	}
+	return weights(q, others, total), true
+}
+
+// mixedWeaklyDominated solves
+//
+//	max Σ_j (Σ_k p_k a_kj - a_ij)  s.t.  Σ_k p_k a_kj >= a_ij, Σp = 1, p >= 0
+//
+// and reports domination when the mixture is somewhere strictly better.
+func mixedWeaklyDominated(m [][]float64, i int) ([]Weight, bool) {
+	others := othersOf(len(m), i)
+	cols := len(m[0])
+
+	c := make([]float64, len(others))
+	for k, r := range others {
+		for j := 0; j < cols; j++ {
+			c[k] += m[r][j]
+		}
+	}
+	Aub := make([][]float64, 0, cols+2)
+	bub := make([]float64, 0, cols+2)
+	for j := 0; j < cols; j++ {
+		row := make([]float64, len(others))
+		for k, r := range others {
+			row[k] = -m[r][j]
+		}
+		Aub = append(Aub, row)
+		bub = append(bub, -m[i][j])
+	}
+	ones := make([]float64, len(others))
+	negOnes := make([]float64, len(others))
+	for k := range ones {
+		ones[k] = 1
+		negOnes[k] = -1
+	}
+	Aub = append(Aub, ones, negOnes)
+	bub = append(bub, 1, -1)
+
+	p, err := lp.SolveLP(c, Aub, bub, true)
+	if err != nil {
+		return nil, false
+	}
+	gain := 0.0
+	for j := 0; j < cols; j++ {
+		for k, r := range others {
+			gain += p[k] * m[r][j]
+		}
+		gain -= m[i][j]
+	}
+	if gain <= dominanceTol {
+		return nil, false
+	}
+	return weights(p, others, sum(p)), true
+}
+
+func othersOf(n, i int) []int {
+	res := make([]int, 0, n-1)
+	for k := 0; k < n; k++ {
+		if k != i {
+			res = append(res, k)
+		}
+	}
+	return res
+}
+
+func weights(q []float64, idx []int, total float64) []Weight {
+	res := []Weight{}
+	for k, v := range q {
+		if v > dominanceTol {
+			res = append(res, Weight{Index: idx[k], Prob: v / total})
+		}
+	}
+	return res
+}
@@ -0,0 +1,162 @@
+package zerosum
+
+import (
+	"decision-theory/games"
+	"decision-theory/lp"
+	"fmt"
+	"math/big"
+)
+
+// ExactSolution is a Solution in rational numbers.
+type ExactSolution struct {
+	RowStrategy []*big.Rat
+	ColStrategy []*big.Rat
+	Value       *big.Rat
+}
+
+func (s ExactSolution) Float() Solution {
+	value, _ := s.Value.Float64()
+	return Solution{RowStrategy: ratsToFloat(s.RowStrategy), ColStrategy: ratsToFloat(s.ColStrategy), Value: value}
+}
+
+func ToRat(payoff [][]int) [][]*big.Rat {
+	m := make([][]*big.Rat, len(payoff))
+	for i := range payoff {
+		m[i] = make([]*big.Rat, len(payoff[i]))
+		for j := range payoff[i] {
+			m[i][j] = big.NewRat(int64(payoff[i][j]), 1)
+		}
+	}
+	return m
+}
+
+// SolveExact solves the same LPs as Solve with the exact rational simplex,
+// so strategies and value come out as fractions such as 1/3.
+func SolveExact(payoff [][]*big.Rat) (ExactSolution, error) {
+	if len(payoff) == 0 || len(payoff[0]) == 0 {
+		return ExactSolution{}, fmt.Errorf("empty payoff matrix")
+	}
+	rows, cols := len(payoff), len(payoff[0])
+	for i := range payoff {
+		if len(payoff[i]) != cols {
+			return ExactSolution{}, fmt.Errorf("row %d has %d columns, expected %d", i, len(payoff[i]), cols)
+		}
+	}
+
+	// shift so that every entry is at least 1
+	low := payoff[0][0]
+	for i := range payoff {
+		for j := range payoff[i] {
+			if payoff[i][j].Cmp(low) < 0 {
+				low = payoff[i][j]
+			}
+		}
+	}
+	shift := new(big.Rat)
+	if low.Sign() <= 0 {
+		shift.Sub(big.NewRat(1, 1), low)
+	}
+
+	one := big.NewRat(1, 1)
+	minusOne := big.NewRat(-1, 1)
+
+	// player 1: min Σx s.t. -Aᵀx <= -1
+	c := make([]*big.Rat, rows)
+	for i := range c {
+		c[i] = one
+	}
+	Aub := make([][]*big.Rat, cols)
+	bub := make([]*big.Rat, cols)
+	for j := 0; j < cols; j++ {
+		Aub[j] = make([]*big.Rat, rows)
+		for i := 0; i < rows; i++ {
+			v := new(big.Rat).Add(payoff[i][j], shift)
+			Aub[j][i] = v.Neg(v)
+		}
+		bub[j] = minusOne
+	}
+	x, sumX, err := lp.SolveLPRat(c, Aub, bub, false)
+	if err != nil {
+		return ExactSolution{}, fmt.Errorf("solving for player 1: %w", err)
+	}
+
+	// player 2: max Σy s.t. Ay <= 1
+	c = make([]*big.Rat, cols)
+	for j := range c {
+		c[j] = one
+	}
+	Aub = make([][]*big.Rat, rows)
+	bub = make([]*big.Rat, rows)
+	for i := 0; i < rows; i++ {
+		Aub[i] = make([]*big.Rat, cols)
+		for j := 0; j < cols; j++ {
+			Aub[i][j] = new(big.Rat).Add(payoff[i][j], shift)
+		}
+		bub[i] = one
+	}
+	y, _, err := lp.SolveLPRat(c, Aub, bub, true)
+	if err != nil {
+		return ExactSolution{}, fmt.Errorf("solving for player 2: %w", err)
+	}
+
+	if sumX.Sign() == 0 {
+		return ExactSolution{}, fmt.Errorf("player 1 LP returned a zero strategy")
+	}
+	v := new(big.Rat).Inv(sumX)
+	for i := range x {
+		x[i].Mul(x[i], v)
+	}
+	for j := range y {
+		y[j].Mul(y[j], v)
+	}
+	return ExactSolution{RowStrategy: x, ColStrategy: y, Value: v.Sub(v, shift)}, nil
+}
+
+// CrossCheck solves the game on both paths and describes every
+// discrepancy: a different value, or a float strategy that does not secure
+// the exact value within tol. Optimal strategies need not be unique, so
+// differing but optimal strategies are not reported.
+func CrossCheck(payoff [][]int, tol float64) (ExactSolution, Solution, []string, error) {
+	exact, err := SolveExact(ToRat(payoff))
+	if err != nil {
+		return ExactSolution{}, Solution{}, nil, err
+	}
+	approx, err := Solve(games.ToFloat(payoff))
+	if err != nil {
+		return exact, Solution{}, nil, err
+	}
+
+	a := games.ToFloat(payoff)
+	value, _ := exact.Value.Float64()
+	issues := []string{}
+	if d := approx.Value - value; d > tol || d < -tol {
+		issues = append(issues, fmt.Sprintf("value: exact %s (%.9f), float %.9f", exact.Value.RatString(), value, approx.Value))
+	}
+	for j := range a[0] {
+		got := 0.0
+		for i := range a {
+			got += approx.RowStrategy[i] * a[i][j]
+		}
+		if got < value-tol {
+			issues = append(issues, fmt.Sprintf("float row strategy gets %.9f against column %d, below the value %s", got, j, exact.Value.RatString()))
+		}
+	}
+	for i := range a {
+		got := 0.0
+		for j := range a[i] {
+			got += approx.ColStrategy[j] * a[i][j]
+		}
+		if got > value+tol {
+			issues = append(issues, fmt.Sprintf("float column strategy loses %.9f against row %d, above the value %s", got, i, exact.Value.RatString()))
+		}
+	}
+	return exact, approx, issues, nil
+}
+
+func ratsToFloat(v []*big.Rat) []float64 {
+	res := make([]float64, len(v))
+	for i, r := range v {
+		res[i], _ = r.Float64()
+	}
+	return res
+}
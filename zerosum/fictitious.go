@@ -0,0 +1,156 @@
+package zerosum
+
+import (
+	"decision-theory/games"
+	"decision-theory/graph"
+	"fmt"
+	"math"
+)
+
+// Iteration is one round of fictitious play: the pure strategies chosen,
+// the value bounds after the round and the empirical strategy frequencies.
+type Iteration struct {
+	Row     int
+	Col     int
+	Lower   float64
+	Upper   float64
+	RowFreq []float64
+	ColFreq []float64
+}
+
+// FictitiousPlay is the result of the Brown–Robinson method. Lower and Upper
+// are the best bounds on the game value seen so far, Value is their midpoint.
+type FictitiousPlay struct {
+	History     []Iteration
+	RowStrategy []float64
+	ColStrategy []float64
+	Lower       float64
+	Upper       float64
+	Value       float64
+	// Converged is false when maxIter rounds were played without reaching
+	// the requested gap.
+	Converged bool
+}
+
+// BrownRobinson approximates the solution by fictitious play: in every
+// round each player picks a pure best response to the opponent's choices so
+// far. After k rounds min(Σ row payoffs)/k is a lower and max(Σ column
+// payoffs)/k an upper bound on the value; the play stops once the best
+// bounds are within eps.
+func BrownRobinson(payoff [][]float64, eps float64, maxIter int) (FictitiousPlay, error) {
+	if err := games.ValidatePayoff(payoff); err != nil {
+		return FictitiousPlay{}, err
+	}
+	if eps <= 0 || maxIter <= 0 {
+		return FictitiousPlay{}, fmt.Errorf("epsilon and iteration limit must be positive, got %v and %d", eps, maxIter)
+	}
+
+	rows, cols := len(payoff), len(payoff[0])
+	// gains[i] is the total the row strategy i would have won against the
+	// column player's choices, losses[j] the total the column strategy j
+	// would have paid against the row player's choices
+	gains := make([]float64, rows)
+	losses := make([]float64, cols)
+	rowCount := make([]int, rows)
+	colCount := make([]int, cols)
+
+	res := FictitiousPlay{History: []Iteration{}, Lower: math.Inf(-1), Upper: math.Inf(1)}
+	i := 0
+	for k := 1; k <= maxIter; k++ {
+		if k > 1 {
+			i = argmax(gains)
+		}
+		for j := range losses {
+			losses[j] += payoff[i][j]
+		}
+		j := argmin(losses)
+		for r := range gains {
+			gains[r] += payoff[r][j]
+		}
+		rowCount[i]++
+		colCount[j]++
+
+		lower := losses[j] / float64(k)
+		upper := gains[argmax(gains)] / float64(k)
+		res.Lower = math.Max(res.Lower, lower)
+		res.Upper = math.Min(res.Upper, upper)
+
+		res.History = append(res.History, Iteration{
+			Row:     i,
+			Col:     j,
+			Lower:   lower,
+			Upper:   upper,
+			RowFreq: frequencies(rowCount, k),
+			ColFreq: frequencies(colCount, k),
+		})
+
+		if res.Upper-res.Lower <= eps {
+			res.Converged = true
+			break
+		}
+	}
+
+	last := res.History[len(res.History)-1]
+	res.RowStrategy = last.RowFreq
+	res.ColStrategy = last.ColFreq
+	res.Value = (res.Lower + res.Upper) / 2
+	return res, nil
+}
+
+// Plot adds the per-iteration lower and upper bounds to g, solid and dotted,
+// with the final value as a reference line; the caller draws and saves the
+// graph.
+func (f FictitiousPlay) Plot(g *graph.Graph) {
+	x := make([]float64, len(f.History))
+	lower := make([]float64, len(f.History))
+	upper := make([]float64, len(f.History))
+	for k, it := range f.History {
+		x[k] = float64(k + 1)
+		lower[k] = it.Lower
+		upper[k] = it.Upper
+	}
+
+	lowerLS := graph.NewLS()
+	lowerLS.Solid()
+	g.Plot(x, lower, lowerLS)
+	g.Name("lower bound")
+
+	upperLS := graph.NewLS()
+	upperLS.Dots(1.5)
+	g.Plot(x, upper, upperLS)
+	g.Name("upper bound")
+
+	// the value the bounds close in on, as a thin reference line
+	valueLS := graph.NewLS()
+	valueLS.Solid(1)
+	g.Plot([]float64{x[0], x[len(x)-1]}, []float64{f.Value, f.Value}, valueLS)
+	g.Name(fmt.Sprintf("value %.4f", f.Value))
+}
+
+func frequencies(count []int, k int) []float64 {
+	res := make([]float64, len(count))
+	for i, c := range count {
+		res[i] = float64(c) / float64(k)
+	}
+	return res
+}
+
+func argmax(v []float64) int {
+	best := 0
+	for i := range v {
+		if v[i] > v[best] {
+			best = i
+		}
+	}
+	return best
+}
+
+func argmin(v []float64) int {
+	best := 0
+	for i := range v {
+		if v[i] < v[best] {
+			best = i
+		}
+	}
+	return best
+}
@@ -0,0 +1,190 @@
+package zerosum
+
+import (
+	"decision-theory/games"
+	"decision-theory/graph"
+	"fmt"
+	"math"
+	"slices"
+)
+
+const graphicalTol = 1e-9
+
+// Graphical is the textbook solution of a 2×n or m×2 game. The player with
+// two strategies mixes them with probability t on the first one; every
+// strategy k of the opponent gives the line Lines[k][0]·(1-t) + Lines[k][1]·t.
+// For 2×n games the row player maximises the lower envelope of the lines,
+// for m×2 games the column player minimises the upper one.
+type Graphical struct {
+	Player   int
+	Lines    [][2]float64
+	Envelope [][2]float64
+	T        float64
+	Value    float64
+	// Active are the opponent's strategies whose lines pass through the
+	// optimum.
+	Active   []int
+	Solution Solution
+}
+
+// SolveGraphically builds the envelope, finds its optimum and the
+// opponent's optimal mix of at most two active strategies.
+func SolveGraphically(payoff [][]float64) (Graphical, error) {
+	if err := games.ValidatePayoff(payoff); err != nil {
+		return Graphical{}, err
+	}
+
+	rows, cols := len(payoff), len(payoff[0])
+	res := Graphical{}
+	// the envelope is always maximised from below, so the lines of an m×2
+	// game are negated
+	sign := 1.0
+	switch {
+	case rows == 2:
+		res.Player = RowPlayer
+		for j := 0; j < cols; j++ {
+			res.Lines = append(res.Lines, [2]float64{payoff[1][j], payoff[0][j]})
+		}
+	case cols == 2:
+		res.Player = ColPlayer
+		sign = -1
+		for i := 0; i < rows; i++ {
+			res.Lines = append(res.Lines, [2]float64{payoff[i][1], payoff[i][0]})
+		}
+	default:
+		return Graphical{}, fmt.Errorf("graphical method needs 2 rows or 2 columns, got %dx%d", rows, cols)
+	}
+
+	lines := make([][2]float64, len(res.Lines))
+	for k, l := range res.Lines {
+		lines[k] = [2]float64{sign * l[0], sign * l[1]}
+	}
+
+	best := math.Inf(-1)
+	for _, t := range breakpoints(lines) {
+		v := lowerEnvelope(lines, t)
+		res.Envelope = append(res.Envelope, [2]float64{t, sign * v})
+		if v > best+graphicalTol {
+			best, res.T = v, t
+		}
+	}
+	res.Value = sign * best
+
+	weights := make([]float64, len(lines))
+	for k, l := range lines {
+		if math.Abs(at(l, res.T)-best) <= graphicalTol {
+			res.Active = append(res.Active, k)
+		}
+	}
+	k, l, w := opponentMix(lines, res.Active, res.T)
+	weights[k] += w
+	weights[l] += 1 - w
+
+	mix := []float64{res.T, 1 - res.T}
+	if res.Player == RowPlayer {
+		res.Solution = Solution{RowStrategy: mix, ColStrategy: weights, Value: res.Value}
+	} else {
+		res.Solution = Solution{RowStrategy: weights, ColStrategy: mix, Value: res.Value}
+	}
+	return res, nil
+}
+
+func at(l [2]float64, t float64) float64 {
+	return l[0]*(1-t) + l[1]*t
+}
+
+func lowerEnvelope(lines [][2]float64, t float64) float64 {
+	v := math.Inf(1)
+	for _, l := range lines {
+		v = math.Min(v, at(l, t))
+	}
+	return v
+}
+
+// breakpoints returns 0, 1 and every pairwise intersection in between,
+// sorted; the envelope is linear between consecutive points.
+func breakpoints(lines [][2]float64) []float64 {
+	ts := []float64{0, 1}
+	for a := range lines {
+		for b := a + 1; b < len(lines); b++ {
+			// l_a(t) - l_b(t) = d0 + (d1 - d0)t
+			d0 := lines[a][0] - lines[b][0]
+			d1 := lines[a][1] - lines[b][1]
+			if math.Abs(d1-d0) <= graphicalTol {
+				continue
+			}
+			if t := d0 / (d0 - d1); t > 0 && t < 1 {
+				ts = append(ts, t)
+			}
+		}
+	}
+	slices.Sort(ts)
+	return slices.CompactFunc(ts, func(a, b float64) bool {
+		return math.Abs(a-b) <= graphicalTol
+	})
+}
+
+// opponentMix picks active lines k and l and the weight w on k such that the
+// mix is flat at an interior optimum, or does not rise towards the inside
+// at an endpoint one.
+func opponentMix(lines [][2]float64, active []int, t float64) (int, int, float64) {
+	slope := func(k int) float64 {
+		return lines[k][1] - lines[k][0]
+	}
+
+	for _, k := range active {
+		s := slope(k)
+		switch {
+		case math.Abs(s) <= graphicalTol,
+			t >= 1-graphicalTol && s >= 0,
+			t <= graphicalTol && s <= 0:
+			return k, k, 1
+		}
+	}
+
+	for _, k := range active {
+		for _, l := range active {
+			if sk, sl := slope(k), slope(l); sk > 0 && sl < 0 {
+				return k, l, -sl / (sk - sl)
+			}
+		}
+	}
+	return active[0], active[0], 1
+}
+
+// RenderPNG draws every strategy line, the envelope and the optimum.
+// labels name the opponent's strategies and may be nil.
+func (g Graphical) RenderPNG(filename string, labels []string, width, height int) error {
+	if width <= 0 || height <= 0 {
+		width, height = 800, 400
+	}
+	gr := graph.NewGraph(width, height)
+
+	line := graph.NewLS()
+	line.Solid(1)
+	for k, l := range g.Lines {
+		name := fmt.Sprintf("%d", k)
+		if k < len(labels) {
+			name = labels[k]
+		}
+		gr.Plot([]float64{0, 1}, []float64{l[0], l[1]}, line, []string{name, ""})
+	}
+
+	x := make([]float64, len(g.Envelope))
+	y := make([]float64, len(g.Envelope))
+	for k, p := range g.Envelope {
+		x[k], y[k] = p[0], p[1]
+	}
+	envelope := graph.NewLS()
+	envelope.Solid(4)
+	gr.Plot(x, y, envelope)
+
+	optimum := graph.NewLS()
+	optimum.Dots(6)
+	gr.Plot([]float64{g.T}, []float64{g.Value}, optimum, []string{fmt.Sprintf("t=%.3f, v=%.3f", g.T, g.Value)})
+
+	if err := gr.Draw(); err != nil {
+		return err
+	}
+	return gr.SavePNG(filename)
+}
@@ -0,0 +1,72 @@
+package zerosum
+
+// SaddlePoint reports whether the game has a pure-strategy equilibrium,
+// its value and every saddle position (row, column).
+func SaddlePoint[T int | float64](m [][]T) (bool, T, [][2]int) {
+	// empty matrix
+	if len(m) == 0 || len(m[0]) == 0 {
+		return false, 0, nil
+	}
+
+	rows := len(m)
+	cols := len(m[0])
+
+	// compute row minima
+	rowMins := make([]T, rows)
+	for i := 0; i < rows; i++ {
+		min := m[i][0]
+		for j := 1; j < cols; j++ {
+			if m[i][j] < min {
+				min = m[i][j]
+			}
+		}
+		rowMins[i] = min
+	}
+
+	// compute max of row minima (maximin)
+	maxOfRowMins := rowMins[0]
+	for i := 1; i < rows; i++ {
+		if rowMins[i] > maxOfRowMins {
+			maxOfRowMins = rowMins[i]
+		}
+	}
+
+	// compute column maxima
+	colMaxs := make([]T, cols)
+	for j := 0; j < cols; j++ {
+		max := m[0][j]
+		for i := 1; i < rows; i++ {
+			if m[i][j] > max {
+				max = m[i][j]
+			}
+		}
+		colMaxs[j] = max
+	}
+
+	// compute min of column maxima (minimax)
+	minOfColMaxs := colMaxs[0]
+	for j := 1; j < cols; j++ {
+		if colMaxs[j] < minOfColMaxs {
+			minOfColMaxs = colMaxs[j]
+		}
+	}
+
+	// saddle exists only if maximin == minimax
+	if maxOfRowMins != minOfColMaxs {
+		return false, 0, nil
+	}
+
+	value := maxOfRowMins
+	positions := make([][2]int, 0)
+
+	// collect all positions that are equilibrium (value, row-min and col-max)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			if m[i][j] == value && m[i][j] == rowMins[i] && m[i][j] == colMaxs[j] {
+				positions = append(positions, [2]int{i, j})
+			}
+		}
+	}
+
+	return true, value, positions
+}
@@ -0,0 +1,156 @@
+package zerosum
+
+import (
+	"decision-theory/games"
+	"decision-theory/lp"
+	"fmt"
+	"math"
+)
+
+// Solution of a zero-sum matrix game: optimal mixed strategies of both
+// players and the value paid to the row player.
+type Solution struct {
+	RowStrategy []float64
+	ColStrategy []float64
+	Value       float64
+	// Saddle is set when the game was solved by the pure saddle-point fast
+	// path; SaddlePoints then lists every pure equilibrium.
+	Saddle       bool
+	SaddlePoints [][2]int
+}
+
+func SolveGame(g games.Game) (Solution, error) {
+	return Solve(games.ToFloat(g.Payoff))
+}
+
+// Solve finds optimal mixed strategies. A pure saddle point is tried first;
+// otherwise the matrix is shifted to be strictly positive and both players'
+// LPs are solved:
+//
+//	player 1: min Σx  s.t. Aᵀx >= 1, x >= 0
+//	player 2: max Σy  s.t. Ay <= 1, y >= 0
+func Solve(payoff [][]float64) (Solution, error) {
+	if err := games.ValidatePayoff(payoff); err != nil {
+		return Solution{}, err
+	}
+
+	rows, cols := len(payoff), len(payoff[0])
+	if ok, value, positions := SaddlePoint(payoff); ok {
+		x := make([]float64, rows)
+		y := make([]float64, cols)
+		x[positions[0][0]] = 1
+		y[positions[0][1]] = 1
+		return Solution{RowStrategy: x, ColStrategy: y, Value: value, Saddle: true, SaddlePoints: positions}, nil
+	}
+
+	positive, shift := makePositive(payoff)
+
+	x, err := solvePlayerOne(positive)
+	if err != nil {
+		return Solution{}, fmt.Errorf("player 1 LP: %w", err)
+	}
+	y, err := solvePlayerTwo(positive)
+	if err != nil {
+		return Solution{}, fmt.Errorf("player 2 LP: %w", err)
+	}
+
+	theta := sum(x)
+	phi := sum(y)
+	if theta <= 0 || phi <= 0 {
+		return Solution{}, fmt.Errorf("degenerate LP solution (θ=%v, φ=%v)", theta, phi)
+	}
+	for i := range x {
+		x[i] /= theta
+	}
+	for j := range y {
+		y[j] /= phi
+	}
+
+	return Solution{RowStrategy: x, ColStrategy: y, Value: 1/theta - shift}, nil
+}
+
+// makePositive adds a constant to all elements to make the matrix strictly positive
+func makePositive(m [][]float64) ([][]float64, float64) {
+	minVal := math.Inf(1)
+	for i := range m {
+		for j := range m[i] {
+			minVal = math.Min(minVal, m[i][j])
+		}
+	}
+
+	shift := 0.0
+	if minVal <= 0 {
+		shift = math.Abs(minVal) + 1
+	}
+
+	result := make([][]float64, len(m))
+	for i := range m {
+		result[i] = make([]float64, len(m[i]))
+		for j := range m[i] {
+			result[i][j] = m[i][j] + shift
+		}
+	}
+	return result, shift
+}
+
+func solvePlayerOne(A [][]float64) ([]float64, error) {
+	// minimize Σx subject to Σ_i a_ij x_i >= 1 for every column j
+	b := lp.NewBuilder()
+	x := make([]lp.Var, len(A))
+	objective := lp.Expr{}
+	for i := range A {
+		x[i] = b.Var(fmt.Sprintf("x%d", i+1))
+		objective = objective.AddTerm(1, x[i])
+	}
+	for j := range A[0] {
+		guarantee := lp.Expr{}
+		for i := range A {
+			guarantee = guarantee.AddTerm(A[i][j], x[i])
+		}
+		b.GreaterEqual(fmt.Sprintf("column%d", j+1), guarantee, 1)
+	}
+	b.Minimize(objective)
+
+	return values(b, x)
+}
+
+func solvePlayerTwo(A [][]float64) ([]float64, error) {
+	// maximize Σy subject to Σ_j a_ij y_j <= 1 for every row i
+	b := lp.NewBuilder()
+	y := make([]lp.Var, len(A[0]))
+	objective := lp.Expr{}
+	for j := range A[0] {
+		y[j] = b.Var(fmt.Sprintf("y%d", j+1))
+		objective = objective.AddTerm(1, y[j])
+	}
+	for i := range A {
+		loss := lp.Expr{}
+		for j := range A[i] {
+			loss = loss.AddTerm(A[i][j], y[j])
+		}
+		b.LessEqual(fmt.Sprintf("row%d", i+1), loss, 1)
+	}
+	b.Maximize(objective)
+
+	return values(b, y)
+}
+
+func values(b *lp.Builder, vars []lp.Var) ([]float64, error) {
+	sol, err := b.Solve()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]float64, len(vars))
+	for k, v := range vars {
+		res[k] = sol.Values[v.Name()]
+	}
+	return res, nil
+}
+
+func sum(v []float64) float64 {
+	s := 0.0
+	for _, val := range v {
+		s += val
+	}
+	return s
+}